@@ -0,0 +1,222 @@
+package adyen
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Keys of commonly used additional data fields, that are not modelled as struct fields
+const (
+	AdditionalDataThreeDOffered           = "threeDOffered"
+	AdditionalDataThreeDAuthenticated     = "threeDAuthenticated"
+	AdditionalDataLiabilityShift          = "liabilityShift"
+	AdditionalDataIssuerCountry           = "issuerCountry"
+	AdditionalDataPaymentAccountReference = "paymentAccountReference"
+)
+
+// ExtraData holds additional data keys, that are not modelled by the library
+//
+// Values are kept as strings, non-string JSON values (numbers, booleans, objects) are stored as raw JSON text
+type ExtraData map[string]string
+
+// Get - returns value of a given additional data key
+func (e ExtraData) Get(key string) (string, bool) {
+	v, ok := e[key]
+	return v, ok
+}
+
+// Bool - returns boolean value of a given additional data key, ok is false if key is missing or not a boolean
+func (e ExtraData) Bool(key string) (value bool, ok bool) {
+	v, ok := e[key]
+	if !ok {
+		return false, false
+	}
+
+	value, err := strconv.ParseBool(strings.TrimSpace(v))
+	if err != nil {
+		return false, false
+	}
+
+	return value, true
+}
+
+// ThreeDOffered - whether 3D Secure was offered for the payment
+func (e ExtraData) ThreeDOffered() (bool, bool) {
+	return e.Bool(AdditionalDataThreeDOffered)
+}
+
+// ThreeDAuthenticated - whether shopper was successfully authenticated with 3D Secure
+func (e ExtraData) ThreeDAuthenticated() (bool, bool) {
+	return e.Bool(AdditionalDataThreeDAuthenticated)
+}
+
+// LiabilityShift - whether liability for the payment has shifted to the issuer
+func (e ExtraData) LiabilityShift() (bool, bool) {
+	return e.Bool(AdditionalDataLiabilityShift)
+}
+
+// IssuerCountry - two-letter country code of the card issuer
+func (e ExtraData) IssuerCountry() string {
+	return e[AdditionalDataIssuerCountry]
+}
+
+// PaymentAccountReference - card scheme reference, that stays the same for all tokens of the card
+func (e ExtraData) PaymentAccountReference() string {
+	return e[AdditionalDataPaymentAccountReference]
+}
+
+// UnmarshalJSON - decodes additional data, keys without a struct field are kept in Extra
+func (d *AdditionalData) UnmarshalJSON(data []byte) error {
+	type plain AdditionalData
+
+	var p plain
+	extra, err := unmarshalWithExtra(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = AdditionalData(p)
+	d.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON - encodes additional data together with keys from Extra
+func (d AdditionalData) MarshalJSON() ([]byte, error) {
+	type plain AdditionalData
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+// UnmarshalJSON - decodes notification additional data, keys without a struct field are kept in Extra
+func (d *NotificationAdditionalData) UnmarshalJSON(data []byte) error {
+	type plain NotificationAdditionalData
+
+	var p plain
+	extra, err := unmarshalWithExtra(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = NotificationAdditionalData(p)
+	d.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON - encodes notification additional data together with keys from Extra
+func (d NotificationAdditionalData) MarshalJSON() ([]byte, error) {
+	type plain NotificationAdditionalData
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+// UnmarshalJSON - decodes recurring detail additional data, keys without a struct field are kept in Extra
+func (d *RecurringDetailAdditionalData) UnmarshalJSON(data []byte) error {
+	type plain RecurringDetailAdditionalData
+
+	var p plain
+	extra, err := unmarshalWithExtra(data, &p)
+	if err != nil {
+		return err
+	}
+
+	*d = RecurringDetailAdditionalData(p)
+	d.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON - encodes recurring detail additional data together with keys from Extra
+func (d RecurringDetailAdditionalData) MarshalJSON() ([]byte, error) {
+	type plain RecurringDetailAdditionalData
+	return marshalWithExtra(plain(d), d.Extra)
+}
+
+// unmarshalWithExtra - decodes JSON object into v and returns all keys, that don't match any of v fields
+//
+// v must be a pointer to a struct without custom UnmarshalJSON method
+func unmarshalWithExtra(data []byte, v interface{}) (ExtraData, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	var extra ExtraData
+	for key, value := range raw {
+		if _, ok := known[key]; ok {
+			continue
+		}
+
+		if extra == nil {
+			extra = make(ExtraData)
+		}
+
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		extra[key] = s
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra - encodes v as JSON object and adds extra keys, struct fields take precedence
+func marshalWithExtra(v interface{}, extra ExtraData) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	for key, value := range extra {
+		if _, ok := raw[key]; ok {
+			continue
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		raw[key] = encoded
+	}
+
+	return json.Marshal(raw)
+}
+
+// jsonFieldNames - returns JSON keys of all exported struct fields
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{}, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		}
+
+		names[name] = struct{}{}
+	}
+
+	return names
+}
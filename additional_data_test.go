@@ -0,0 +1,91 @@
+package adyen
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAdditionalDataUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	input := `{
+		"cardSummary": "1111",
+		"threeDAuthenticated": "true",
+		"threeDOffered": "false",
+		"issuerCountry": "NL",
+		"paymentAccountReference": "V0010013816180398947200015102",
+		"retry.attempt1.rawResponse": "AUTHORISED",
+		"fraudScore": 12
+	}`
+
+	var data AdditionalData
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	equals(t, "1111", data.CardSummary)
+	equals(t, "NL", data.Extra.IssuerCountry())
+	equals(t, "V0010013816180398947200015102", data.Extra.PaymentAccountReference())
+
+	authenticated, found := data.Extra.ThreeDAuthenticated()
+	assert(t, found && authenticated, "expected threeDAuthenticated to be true")
+	offered, found := data.Extra.ThreeDOffered()
+	assert(t, found && !offered, "expected threeDOffered to be false")
+	_, found = data.Extra.LiabilityShift()
+	assert(t, !found, "expected liabilityShift to be missing")
+
+	v, found := data.Extra.Get("fraudScore")
+	assert(t, found, "expected fraudScore to be kept")
+	equals(t, "12", v)
+
+	_, found = data.Extra.Get("cardSummary")
+	assert(t, !found, "expected modelled keys not to be duplicated in Extra")
+
+	out, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("error marshalling json: %v", err)
+	}
+
+	var roundTrip map[string]interface{}
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	equals(t, "1111", roundTrip["cardSummary"])
+	equals(t, "AUTHORISED", roundTrip["retry.attempt1.rawResponse"])
+	equals(t, "NL", roundTrip["issuerCountry"])
+}
+
+func TestAdditionalDataMarshalFieldsTakePrecedence(t *testing.T) {
+	t.Parallel()
+
+	data := AdditionalData{
+		CardSummary: "1111",
+		Extra:       ExtraData{"cardSummary": "2222", "customKey": "value"},
+	}
+
+	out, err := json.Marshal(&data)
+	if err != nil {
+		t.Fatalf("error marshalling json: %v", err)
+	}
+
+	var roundTrip map[string]string
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	equals(t, map[string]string{"cardSummary": "1111", "customKey": "value"}, roundTrip)
+}
+
+func TestNotificationAdditionalDataUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	input := `{"additionalData": {"hmacSignature": "sig", "issuerCountry": "US"}, "eventCode": "AUTHORISATION"}`
+
+	var item NotificationRequestItemData
+	if err := json.Unmarshal([]byte(input), &item); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	equals(t, "sig", item.AdditionalData.HmacSignature)
+	equals(t, "US", item.AdditionalData.Extra.IssuerCountry())
+	equals(t, ExtraData{"issuerCountry": "US"}, item.AdditionalData.Extra)
+}
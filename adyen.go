@@ -3,7 +3,9 @@ package adyen
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"time"
 )
@@ -22,6 +24,12 @@ const (
 	// RecurringAPIVersion - API version of current recurring API
 	RecurringAPIVersion = "v49"
 
+	// RecurringNotifyShopperAPIVersion - API version of recurring API with shopper notifications
+	RecurringNotifyShopperAPIVersion = "v68"
+
+	// RecurringPermitsAPIVersion - API version of recurring API with permits
+	RecurringPermitsAPIVersion = "v68"
+
 	// PaymentService is used to identify the standard payment workflow.
 	PaymentService = "Payment"
 
@@ -30,6 +38,15 @@ const (
 
 	// CheckoutAPIVersion - API version of current checkout API
 	CheckoutAPIVersion = "v52"
+
+	// CheckoutSessionsAPIVersion - API version of checkout API with sessions endpoint
+	CheckoutSessionsAPIVersion = "v68"
+
+	// CheckoutModificationsAPIVersion - API version of checkout API with payment modification endpoints
+	CheckoutModificationsAPIVersion = "v67"
+
+	// CheckoutPaymentLinksAPIVersion - API version of checkout API with payment link retrieval and update endpoints
+	CheckoutPaymentLinksAPIVersion = "v66"
 )
 
 // Adyen - base structure with configuration options
@@ -38,6 +55,7 @@ const (
 //       - Currency is a default request currency. Request data overrides this setting
 //       - MerchantAccount is default merchant account to be used. Request data overrides this setting
 //       - client is http client instance
+//       - retryPolicy is an optional policy to retry failed requests, see WithRetryPolicy
 //
 // Currency and MerchantAccount should be used only to store the data and be able to use it later.
 // Requests won't be automatically populated with given values
@@ -46,7 +64,8 @@ type Adyen struct {
 	Currency        string
 	MerchantAccount string
 
-	client *http.Client
+	client      *http.Client
+	retryPolicy *RetryPolicy
 }
 
 // New - creates Adyen instance
@@ -82,6 +101,23 @@ func NewWithHMAC(env Environment, username, password, hmac string, opts ...Optio
 	return NewWithCredentials(env, creds, opts...)
 }
 
+// NewWithAPIKey - create new Adyen instance authenticated with web service API key
+//
+// Requests are sent with X-API-Key header instead of basic authentication.
+// Use this constructor for Checkout API and newer Adyen APIs.
+//
+// Description:
+//
+//     - env - Environment for next API calls
+//     - apiKey - web service API key
+//     - opts - an optional collection of functions that allow you to tweak configurations.
+//
+// API key can be generated for web service user there: https://ca-test.adyen.com/ca/ca/config/users.shtml
+func NewWithAPIKey(env Environment, apiKey string, opts ...Option) *Adyen {
+	creds := makeCredentialsWithAPIKey(env, apiKey)
+	return NewWithCredentials(env, creds, opts...)
+}
+
 // NewWithCredentials - create new Adyen instance with pre-configured credentials.
 //
 // Description:
@@ -132,6 +168,40 @@ func WithCurrency(c string) func(*Adyen) {
 	}
 }
 
+// WithRetryPolicy enables automatic retries of failed API calls.
+//
+// Only network errors, 5xx and 429 responses are retried. Every attempt is sent with the same
+// Idempotency-Key, generated automatically unless provided through WithIdempotencyKey
+func WithRetryPolicy(p RetryPolicy) func(*Adyen) {
+	return func(a *Adyen) {
+		a.retryPolicy = &p
+	}
+}
+
+// WithHMACVerificationKey adds HMAC key to validate signatures within a given validity window.
+//
+// Signatures are still calculated with the primary key passed to NewWithHMAC, so during key rotation
+// the new key is set as primary and the old one is accepted until it expires:
+//
+//	instance := adyen.NewWithHMAC(adyen.Testing, username, password, newKey,
+//		adyen.WithHMACVerificationKey(oldKey, time.Time{}, rotatedAt.Add(time.Hour*24)))
+//
+// Zero notBefore or notAfter means the window is not limited from that side
+func WithHMACVerificationKey(key string, notBefore, notAfter time.Time) func(*Adyen) {
+	return func(a *Adyen) {
+		a.Credentials.VerificationKeys = append(a.Credentials.VerificationKeys, HMACKey{
+			Key:       key,
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		})
+	}
+}
+
+// NotificationVerifier - returns verifier with primary HMAC key and verification keys valid at the moment
+func (a *Adyen) NotificationVerifier() (*NotificationVerifier, error) {
+	return NewNotificationVerifier(a.Credentials.hmacKeys(time.Now())...)
+}
+
 // ClientURL - returns URl, that need to loaded in UI, to encrypt Credit Card information
 //
 //           - clientID - Used to load external JS files from Adyen, to encrypt client requests
@@ -158,19 +228,79 @@ func (a *Adyen) checkoutURL(requestType, apiVersion string) string {
 //
 // internal method to do a request to Adyen API endpoint
 // request Type: POST, request body format - JSON
-func (a *Adyen) execute(url string, requestEntity interface{}) (r *Response, err error) {
-	body, err := json.Marshal(requestEntity)
+//
+// Request is bound to a given context of every ...Context method, the call is aborted as soon as
+// ctx is cancelled or its deadline is exceeded.
+// If retry policy is configured, failed attempts are repeated with the same Idempotency-Key
+func (a *Adyen) execute(ctx context.Context, url string, requestEntity interface{}) (*Response, error) {
+	return a.executeMethod(ctx, http.MethodPost, url, requestEntity)
+}
+
+// executeMethod - execute request on Adyen side with a given HTTP method
+//
+// internal method to do a request to Adyen API endpoint, request body format - JSON.
+// Request is sent without a body if "requestEntity" is nil, f.e. for GET requests
+func (a *Adyen) executeMethod(ctx context.Context, method, url string, requestEntity interface{}) (*Response, error) {
+	var (
+		body []byte
+		err  error
+	)
+	if requestEntity != nil {
+		if body, err = json.Marshal(requestEntity); err != nil {
+			return nil, err
+		}
+	}
+
+	key := idempotencyKeyFromContext(ctx)
+	if key == "" && a.retryPolicy != nil {
+		if key, err = newIdempotencyKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	var r *Response
+	for attempt := 1; ; attempt++ {
+		r, err = a.send(ctx, method, url, body, key)
+		if !a.retryPolicy.shouldRetry(ctx, attempt, r, err) {
+			break
+		}
+
+		if werr := a.retryPolicy.wait(ctx, attempt); werr != nil {
+			return nil, werr
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err = r.handleHTTPError(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// send - perform a single request attempt to Adyen API endpoint
+func (a *Adyen) send(ctx context.Context, method, url string, body []byte, idempotencyKey string) (r *Response, err error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(a.Credentials.Username, a.Credentials.Password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
+	a.Credentials.authorize(req)
 
 	resp, err := a.client.Do(req)
 	if err != nil {
@@ -192,21 +322,18 @@ func (a *Adyen) execute(url string, requestEntity interface{}) (r *Response, err
 		Body:     buf.Bytes(),
 	}
 
-	if err = r.handleHTTPError(); err != nil {
-		return nil, err
-	}
-
 	return
 }
 
 // executeHpp - execute request without authorization to Adyen Hosted Payment API
 //
 // internal method to request Adyen HPP API via GET
-func (a *Adyen) executeHpp(url string, requestEntity interface{}) (r *Response, err error) {
+func (a *Adyen) executeHpp(ctx context.Context, url string, requestEntity interface{}) (r *Response, err error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	resp, err := a.client.Do(req)
 
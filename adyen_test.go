@@ -2,11 +2,14 @@ package adyen
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -59,6 +62,176 @@ func TestNewWithCustomOptions(t *testing.T) {
 	equals(t, timeout, act.client.Timeout)
 }
 
+func TestNewWithAPIKey(t *testing.T) {
+	act := NewWithAPIKey(Testing, "api-key")
+	equals(t, "api-key", act.Credentials.APIKey)
+	equals(t, "", act.Credentials.Username)
+}
+
+func TestExecuteAuthentication(t *testing.T) {
+	cases := []struct {
+		name        string
+		credentials apiCredentials
+		expAPIKey   string
+		expUsername string
+		expPassword string
+		expBasic    bool
+	}{
+		{
+			name:        "basic authentication",
+			credentials: makeCredentials(Testing, "username", "password"),
+			expUsername: "username",
+			expPassword: "password",
+			expBasic:    true,
+		},
+		{
+			name:        "api key authentication",
+			credentials: makeCredentialsWithAPIKey(Testing, "api-key"),
+			expAPIKey:   "api-key",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var req *http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				fmt.Fprint(w, `{"paymentMethods":[]}`)
+			}))
+			defer srv.Close()
+
+			c.credentials.Env = Environment{checkoutURL: srv.URL}
+			instance := NewWithCredentials(c.credentials.Env, c.credentials)
+
+			if _, err := instance.Checkout().PaymentMethods(&PaymentMethods{}); err != nil {
+				t.Fatal(err)
+			}
+
+			username, password, basic := req.BasicAuth()
+			equals(t, c.expAPIKey, req.Header.Get(apiKeyHeader))
+			equals(t, c.expBasic, basic)
+			equals(t, c.expUsername, username)
+			equals(t, c.expPassword, password)
+		})
+	}
+}
+
+func TestExecuteContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	instance := getTestInstanceWithServer(srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	_, err := instance.Modification().CaptureContext(ctx, &Capture{
+		ModificationAmount: &Amount{Value: 1000, Currency: "EUR"},
+		MerchantAccount:    "merchant",
+		OriginalReference:  "8313842560770001",
+	})
+	if err == nil {
+		t.Fatal("Request should fail, due to cancelled context")
+	}
+
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Fatalf("Context deadline should be exceeded, got %v", ctx.Err())
+	}
+}
+
+func TestExecuteContextPassesResponse(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, `{"pspReference":"8413547924770610","resultCode":"Authorised"}`)
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	res, err := instance.Payment().AuthoriseContext(context.Background(), &Authorise{
+		Amount:          &Amount{Value: 1000, Currency: "EUR"},
+		Reference:       "DE-TEST-1",
+		MerchantAccount: "merchant",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Payment/"+PaymentAPIVersion+"/authorise/", path)
+	equals(t, "8413547924770610", res.PspReference)
+	equals(t, "Authorised", res.ResultCode)
+}
+
+func TestHMACKeyValidAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cases := []struct {
+		name string
+		key  HMACKey
+		exp  bool
+	}{
+		{name: "unlimited", key: HMACKey{}, exp: true},
+		{name: "not yet valid", key: HMACKey{NotBefore: now.Add(time.Hour)}, exp: false},
+		{name: "expired", key: HMACKey{NotAfter: now.Add(-time.Hour)}, exp: false},
+		{name: "within window", key: HMACKey{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}, exp: true},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			equals(t, c.exp, c.key.ValidAt(now))
+		})
+	}
+}
+
+func TestHMACVerificationKey(t *testing.T) {
+	t.Parallel()
+
+	var req NotificationRequest
+	if err := json.Unmarshal([]byte(testNotificationJSON), &req); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+	item := req.NotificationItems[0].NotificationRequestItem
+
+	// notification is signed with testNotificationHMAC, the key being rotated out,
+	// while testNotificationNewHMAC is already configured as primary key
+	now := time.Now()
+
+	cases := []struct {
+		name     string
+		notAfter time.Time
+		exp      bool
+	}{
+		{name: "old key accepted during rollover", notAfter: now.Add(time.Hour), exp: true},
+		{name: "old key expired", notAfter: now.Add(-time.Hour), exp: false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			instance := NewWithHMAC(Testing, "username", "password", testNotificationNewHMAC,
+				WithHMACVerificationKey(testNotificationHMAC, time.Time{}, c.notAfter))
+
+			valid, err := item.ValidateSignature(instance)
+			equals(t, nil, err)
+			equals(t, c.exp, valid)
+		})
+	}
+}
+
 func equals(tb *testing.T, exp interface{}, act interface{}) {
 	_, fullPath, line, _ := runtime.Caller(1)
 	file := filepath.Base(fullPath)
@@ -100,6 +273,18 @@ func getTestInstanceWithHPP() *Adyen {
 	return instance
 }
 
+// getTestInstanceWithServer - instanciate adyen for tests, all API calls are routed to a given test server
+func getTestInstanceWithServer(srv *httptest.Server) *Adyen {
+	env := Environment{
+		apiURL:      srv.URL,
+		clientURL:   srv.URL + "/cse/js/",
+		hppURL:      srv.URL + "/hpp/",
+		checkoutURL: srv.URL,
+	}
+
+	return New(env, "username", "password")
+}
+
 // randInt - get random integer from a given range
 func randInt(min int, max int) int {
 	return min + rand.Intn(max-min)
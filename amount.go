@@ -1,19 +1,39 @@
 package adyen
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
 
 // Amount value/currency representation
+//
+// Value is specified in minor units of a currency, f.e. 10,30 EUR = 1030
 type Amount struct {
-	Value    float32 `json:"value"`
-	Currency string  `json:"currency"`
+	Value    int64  `json:"value"`
+	Currency string `json:"currency"`
 }
 
 var (
-	// DefaultCurrencyDecimals - default currency decimals
+	// DefaultCurrencyDecimals - default currency decimals, used for codes missing in Currencies table
 	DefaultCurrencyDecimals uint = 2
 
+	// AdyenCurrencyDecimals - currencies, Adyen expects in a different number of decimals than ISO 4217 minor units
+	//
+	// Link - https://docs.adyen.com/development-resources/currency-codes
+	AdyenCurrencyDecimals = map[string]uint{
+		"CLP": 2,
+		"CVE": 0,
+		"IDR": 0,
+		"ISK": 2,
+	}
+
 	// CurrencyDecimals - https://docs.adyen.com/developers/currency-codes
 	// currencies with 2 decimals stripped out
+	//
+	// Deprecated: decimals are taken from ISO 4217 MinorUnits of Currencies and AdyenCurrencyDecimals overrides,
+	// the map is not used anymore and kept for compatibility only.
 	CurrencyDecimals = map[string]uint{
 		"BHD": 3,
 		"CVE": 0,
@@ -41,25 +61,82 @@ var (
 
 // NewAmount - creates Amount instance
 //
-// Automatically adjust decimal points for the float value
+// Automatically adjust decimal points for the float value,
+// an error is returned if currency is not a known ISO 4217 currency code
+//
+// NOTE: float32 can't represent big amounts exactly, use NewAmountFromString for exact conversion
+//
 // Link - https://docs.adyen.com/developers/development-resources/currency-codes
-func NewAmount(currency string, amount float32) *Amount {
-	decimals, ok := CurrencyDecimals[currency]
-	if !ok {
-		decimals = DefaultCurrencyDecimals
+func NewAmount(currency string, amount float32) (*Amount, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
 	}
 
-	if decimals == 0 {
-		return &Amount{
-			Currency: currency,
-			Value:    amount,
-		}
+	coef := math.Pow10(int(currencyDecimals(currency)))
+
+	return &Amount{
+		Currency: currency,
+		Value:    int64(math.Round(float64(amount) * coef)),
+	}, nil
+}
+
+// NewAmountFromString - creates Amount instance from a decimal string in major units, f.e. "10.30"
+//
+// Conversion is exact, decimal points are adjusted according to Currency.Decimals.
+// An error is returned if currency is unknown, value is malformed or has more decimals than currency supports
+func NewAmountFromString(currency, value string) (*Amount, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	decimals := int(currencyDecimals(currency))
+
+	integer, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		integer, fraction = value[:i], value[i+1:]
 	}
 
-	coef := float32(math.Pow(10, float64(decimals)))
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals allowed for %s currency", value, decimals, currency)
+	}
+
+	minor, err := strconv.ParseInt(integer+fraction+strings.Repeat("0", decimals-len(fraction)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %v", value, err)
+	}
 
 	return &Amount{
 		Currency: currency,
-		Value:    float32(math.Round(float64(amount * coef))),
+		Value:    minor,
+	}, nil
+}
+
+// currencyDecimals - number of decimal points Adyen expects for a given currency
+//
+// Adyen overrides take precedence over ISO 4217 minor units
+func currencyDecimals(currency string) uint {
+	if decimals, ok := AdyenCurrencyDecimals[currency]; ok {
+		return decimals
 	}
+
+	if c, ok := Currencies[currency]; ok {
+		return c.MinorUnits
+	}
+
+	return DefaultCurrencyDecimals
+}
+
+// isDigits - checks that a given string consists of ASCII digits only
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
 }
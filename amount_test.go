@@ -10,51 +10,67 @@ func TestNewAmount(t *testing.T) {
 		name     string
 		currency string
 		amount   float32
-		expected Amount
+		expected *Amount
+		expErr   bool
 	}{
 		{
 			name:     "Test EUR currency",
 			currency: "EUR",
 			amount:   10.50,
-			expected: Amount{Currency: "EUR", Value: 1050},
+			expected: &Amount{Currency: "EUR", Value: 1050},
 		},
 		{
 			name:     "Test EUR currency, zero case",
 			currency: "EUR",
 			amount:   0,
-			expected: Amount{Currency: "EUR", Value: 0},
+			expected: &Amount{Currency: "EUR", Value: 0},
 		},
 		{
-			name:     "Test unknown (UKN) currency, default should be used",
+			name:     "Test unknown (UKN) currency",
 			currency: "UKN",
 			amount:   10.60,
-			expected: Amount{Currency: "UKN", Value: 1060},
+			expErr:   true,
+		},
+		{
+			name:     "Test ISK currency, Adyen expects 2 decimals",
+			currency: "ISK",
+			amount:   150,
+			expected: &Amount{Currency: "ISK", Value: 15000},
 		},
 		{
 			name:     "Test CVE currency with zero decimal adjustment",
 			currency: "CVE",
 			amount:   150,
-			expected: Amount{Currency: "CVE", Value: 150},
+			expected: &Amount{Currency: "CVE", Value: 150},
 		},
 		{
 			name:     "Test BHD currency with 3 decimal adjustment points",
 			currency: "BHD",
 			amount:   150.050,
-			expected: Amount{Currency: "BHD", Value: 150050},
+			expected: &Amount{Currency: "BHD", Value: 150050},
+		},
+		{
+			name:     "Test BIF currency without decimals",
+			currency: "BIF",
+			amount:   150,
+			expected: &Amount{Currency: "BIF", Value: 150},
 		},
 		{
 			name:     "Test correct float32 conversion",
 			currency: "EUR",
 			amount:   8.40,
-			expected: Amount{Currency: "EUR", Value: 840},
+			expected: &Amount{Currency: "EUR", Value: 840},
 		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			a := NewAmount(c.currency, c.amount)
+			a, err := NewAmount(c.currency, c.amount)
+			if (err != nil) != c.expErr {
+				t.Fatalf("expected error?: %t, actual error: %v", c.expErr, err)
+			}
 
-			equals(t, c.expected, *a)
+			equals(t, c.expected, a)
 		})
 	}
 }
@@ -76,7 +92,118 @@ func TestAmount_UnmarshalJson(t *testing.T) {
 	if amount.Currency != "KWD" {
 		t.Fatalf("expected currency KWD, but got %s in unmarshaled struct %+v", amount.Currency, amount)
 	}
-	if amount.Value != 87230.0 {
-		t.Fatalf("expected value 87230.0, but got %f in unmarshaled struct %+v", amount.Value, amount)
+	if amount.Value != 87230 {
+		t.Fatalf("expected value 87230, but got %d in unmarshaled struct %+v", amount.Value, amount)
+	}
+}
+
+func TestAmount_MarshalJson(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(Amount{Currency: "IDR", Value: 123456789012})
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+
+	equals(t, `{"value":123456789012,"currency":"IDR"}`, string(b))
+}
+
+func TestNewAmountFromString(t *testing.T) {
+	cases := []struct {
+		name     string
+		currency string
+		amount   string
+		expected *Amount
+		expErr   bool
+	}{
+		{
+			name:     "Test EUR currency",
+			currency: "EUR",
+			amount:   "10.50",
+			expected: &Amount{Currency: "EUR", Value: 1050},
+		},
+		{
+			name:     "Test EUR currency without decimals",
+			currency: "EUR",
+			amount:   "10",
+			expected: &Amount{Currency: "EUR", Value: 1000},
+		},
+		{
+			name:     "Test EUR currency with one decimal",
+			currency: "EUR",
+			amount:   "8.4",
+			expected: &Amount{Currency: "EUR", Value: 840},
+		},
+		{
+			name:     "Test BHD currency with 3 decimal adjustment points",
+			currency: "BHD",
+			amount:   "150.050",
+			expected: &Amount{Currency: "BHD", Value: 150050},
+		},
+		{
+			name:     "Test high value IDR amount, that float32 can't represent",
+			currency: "IDR",
+			amount:   "16777217",
+			expected: &Amount{Currency: "IDR", Value: 16777217},
+		},
+		{
+			name:     "Test high value VND amount",
+			currency: "VND",
+			amount:   "987654321987",
+			expected: &Amount{Currency: "VND", Value: 987654321987},
+		},
+		{
+			name:     "Test too many decimals",
+			currency: "EUR",
+			amount:   "10.505",
+			expErr:   true,
+		},
+		{
+			name:     "Test decimals for zero decimal currency",
+			currency: "JPY",
+			amount:   "100.5",
+			expErr:   true,
+		},
+		{
+			name:     "Test malformed amount",
+			currency: "EUR",
+			amount:   "10,50",
+			expErr:   true,
+		},
+		{
+			name:     "Test empty amount",
+			currency: "EUR",
+			amount:   "",
+			expErr:   true,
+		},
+		{
+			name:     "Test negative amount",
+			currency: "EUR",
+			amount:   "-10.50",
+			expErr:   true,
+		},
+		{
+			name:     "Test unknown currency",
+			currency: "UKN",
+			amount:   "10.50",
+			expErr:   true,
+		},
+		{
+			name:     "Test overflow",
+			currency: "EUR",
+			amount:   "99999999999999999999",
+			expErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, err := NewAmountFromString(c.currency, c.amount)
+			if (err != nil) != c.expErr {
+				t.Fatalf("expected error?: %t, actual error: %v", c.expErr, err)
+			}
+
+			equals(t, c.expected, a)
+		})
 	}
 }
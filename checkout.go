@@ -1,5 +1,7 @@
 package adyen
 
+import "time"
+
 // PaymentMethods contains the fields required by the checkout
 // API's /paymentMethods endpoint.  See the following for more
 // information:
@@ -73,3 +75,399 @@ type PaymentMethodCard struct {
 	HolderName  string `json:"holderName"`
 	Number      string `json:"number"`
 }
+
+/***********
+* Payments *
+***********/
+
+// Checkout API result codes, returned in ResultCode of PaymentsResponse and PaymentDetailsResponse
+//
+// Link - https://docs.adyen.com/development-resources/response-handling
+const (
+	ResultCodeAuthorised                = "Authorised"
+	ResultCodeCancelled                 = "Cancelled"
+	ResultCodeChallengeShopper          = "ChallengeShopper"
+	ResultCodeError                     = "Error"
+	ResultCodeIdentifyShopper           = "IdentifyShopper"
+	ResultCodePending                   = "Pending"
+	ResultCodePresentToShopper          = "PresentToShopper"
+	ResultCodeReceived                  = "Received"
+	ResultCodeRedirectShopper           = "RedirectShopper"
+	ResultCodeRefused                   = "Refused"
+	ResultCodeAuthenticationNotRequired = "AuthenticationNotRequired"
+)
+
+// Checkout API action types, tell Drop-in or Components what to do next
+//
+// Link - https://docs.adyen.com/online-payments/action-component
+const (
+	ActionTypeAwait               = "await"
+	ActionTypeQRCode              = "qrCode"
+	ActionTypeRedirect            = "redirect"
+	ActionTypeSDK                 = "sdk"
+	ActionTypeThreeDS2            = "threeDS2"
+	ActionTypeThreeDS2Challenge   = "threeDS2Challenge"
+	ActionTypeThreeDS2Fingerprint = "threeDS2Fingerprint"
+	ActionTypeVoucher             = "voucher"
+)
+
+// PaymentsRequest contains the fields required by the checkout
+// API's /payments endpoint.
+//
+// Link - https://docs.adyen.com/api-explorer/#/PaymentSetupAndVerificationService/v52/payments
+type PaymentsRequest struct {
+	AdditionalData           *AdditionalData          `json:"additionalData,omitempty"`
+	Amount                   *Amount                  `json:"amount"`
+	BillingAddress           *Address                 `json:"billingAddress,omitempty"`
+	BrowserInfo              *BrowserInfo             `json:"browserInfo,omitempty"`
+	CaptureDelayHours        *int                     `json:"captureDelayHours,omitempty"`
+	Channel                  string                   `json:"channel,omitempty"`
+	CountryCode              string                   `json:"countryCode,omitempty"`
+	DeliveryAddress          *Address                 `json:"deliveryAddress,omitempty"`
+	LineItems                []LineItem               `json:"lineItems,omitempty"` // Required for open invoice payment methods
+	MerchantAccount          string                   `json:"merchantAccount"`
+	Origin                   string                   `json:"origin,omitempty"` // Required for a native 3DS2 process
+	PaymentMethod            *CheckoutPaymentMethod   `json:"paymentMethod"`
+	RecurringProcessingModel RecurringProcessingModel `json:"recurringProcessingModel,omitempty"`
+	Reference                string                   `json:"reference"`
+	ReturnURL                string                   `json:"returnUrl"`
+	ShopperEmail             string                   `json:"shopperEmail,omitempty"`
+	ShopperInteraction       ShopperInteraction       `json:"shopperInteraction,omitempty"`
+	ShopperIP                string                   `json:"shopperIP,omitempty"`
+	ShopperLocale            string                   `json:"shopperLocale,omitempty"`
+	ShopperName              *Name                    `json:"shopperName,omitempty"`
+	ShopperReference         string                   `json:"shopperReference,omitempty"` // Mandatory for recurring payment
+	StorePaymentMethod       bool                     `json:"storePaymentMethod,omitempty"`
+}
+
+// CheckoutPaymentMethod describes the payment method details collected by
+// Drop-in or Components (state.data.paymentMethod).
+//
+// Type is always required, other fields depend on a payment method
+type CheckoutPaymentMethod struct {
+	Type                     string `json:"type"`
+	Brand                    string `json:"brand,omitempty"`
+	EncryptedCardNumber      string `json:"encryptedCardNumber,omitempty"`
+	EncryptedExpiryMonth     string `json:"encryptedExpiryMonth,omitempty"`
+	EncryptedExpiryYear      string `json:"encryptedExpiryYear,omitempty"`
+	EncryptedSecurityCode    string `json:"encryptedSecurityCode,omitempty"`
+	HolderName               string `json:"holderName,omitempty"`
+	Issuer                   string `json:"issuer,omitempty"`
+	RecurringDetailReference string `json:"recurringDetailReference,omitempty"`
+	StoredPaymentMethodID    string `json:"storedPaymentMethodId,omitempty"`
+	SepaOwnerName            string `json:"sepa.ownerName,omitempty"`
+	SepaIBANNumber           string `json:"sepa.ibanNumber,omitempty"`
+}
+
+// Action describes the next step required to complete a payment,
+// f.e. redirect shopper, perform 3D Secure 2 authentication or show QR code.
+//
+// Action object should be passed as is to Drop-in or Components handleAction method.
+type Action struct {
+	Type              string            `json:"type"`
+	PaymentMethodType string            `json:"paymentMethodType,omitempty"`
+	PaymentData       string            `json:"paymentData,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	Method            string            `json:"method,omitempty"`
+	Data              map[string]string `json:"data,omitempty"`
+	Token             string            `json:"token,omitempty"`
+	Subtype           string            `json:"subtype,omitempty"`
+	QRCodeData        string            `json:"qrCodeData,omitempty"`
+}
+
+// PaymentsResponse is returned by Adyen in response to
+// a Payments request.
+//
+// Depending on ResultCode, Action should be handled to complete a payment
+type PaymentsResponse struct {
+	PspReference      string               `json:"pspReference,omitempty"`
+	ResultCode        string               `json:"resultCode"`
+	RefusalReason     string               `json:"refusalReason,omitempty"`
+	RefusalReasonCode string               `json:"refusalReasonCode,omitempty"`
+	MerchantReference string               `json:"merchantReference,omitempty"`
+	Action            *Action              `json:"action,omitempty"`
+	Details           []PaymentMethodTypes `json:"details,omitempty"`
+	PaymentData       string               `json:"paymentData,omitempty"`
+	Amount            *Amount              `json:"amount,omitempty"`
+	FraudResult       *FraudResult         `json:"fraudResult,omitempty"`
+	AdditionalData    *AdditionalData      `json:"additionalData,omitempty"`
+}
+
+/*******************
+* Payments details *
+*******************/
+
+// PaymentDetailsRequest contains the fields required by the checkout
+// API's /payments/details endpoint.
+//
+// Details are returned by Drop-in or Components (state.data.details) or collected from redirect,
+// PaymentData is the value from previous PaymentsResponse
+//
+// Link - https://docs.adyen.com/api-explorer/#/PaymentSetupAndVerificationService/v52/payments/details
+type PaymentDetailsRequest struct {
+	Details                   map[string]string `json:"details"`
+	PaymentData               string            `json:"paymentData,omitempty"`
+	ThreeDSAuthenticationOnly bool              `json:"threeDSAuthenticationOnly,omitempty"`
+}
+
+// PaymentDetailsResponse is returned by Adyen in response to
+// a PaymentDetails request.
+type PaymentDetailsResponse struct {
+	PspReference      string          `json:"pspReference,omitempty"`
+	ResultCode        string          `json:"resultCode"`
+	RefusalReason     string          `json:"refusalReason,omitempty"`
+	RefusalReasonCode string          `json:"refusalReasonCode,omitempty"`
+	MerchantReference string          `json:"merchantReference,omitempty"`
+	Action            *Action         `json:"action,omitempty"`
+	ShopperLocale     string          `json:"shopperLocale,omitempty"`
+	Amount            *Amount         `json:"amount,omitempty"`
+	FraudResult       *FraudResult    `json:"fraudResult,omitempty"`
+	AdditionalData    *AdditionalData `json:"additionalData,omitempty"`
+}
+
+/***********
+* Sessions *
+***********/
+
+// CreateCheckoutSessionRequest contains the fields required by the checkout
+// API's /sessions endpoint, used to start session based Drop-in or Components flow.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/sessions
+type CreateCheckoutSessionRequest struct {
+	Amount                   *Amount                  `json:"amount"`
+	Channel                  string                   `json:"channel,omitempty"`
+	CountryCode              string                   `json:"countryCode,omitempty"`
+	ExpiresAt                *time.Time               `json:"expiresAt,omitempty"` // Session expires in 1 hour by default
+	LineItems                []LineItem               `json:"lineItems,omitempty"`
+	MerchantAccount          string                   `json:"merchantAccount"`
+	RecurringProcessingModel RecurringProcessingModel `json:"recurringProcessingModel,omitempty"`
+	Reference                string                   `json:"reference"`
+	ReturnURL                string                   `json:"returnUrl"`
+	ShopperEmail             string                   `json:"shopperEmail,omitempty"`
+	ShopperInteraction       ShopperInteraction       `json:"shopperInteraction,omitempty"`
+	ShopperLocale            string                   `json:"shopperLocale,omitempty"`
+	ShopperReference         string                   `json:"shopperReference,omitempty"` // Mandatory to store payment method
+	StorePaymentMethod       bool                     `json:"storePaymentMethod,omitempty"`
+}
+
+// CreateCheckoutSessionResponse is returned by Adyen in response to
+// a Sessions request.
+//
+// ID and SessionData need to be passed to Drop-in or Components configuration
+type CreateCheckoutSessionResponse struct {
+	ID               string     `json:"id"`
+	SessionData      string     `json:"sessionData"`
+	Amount           *Amount    `json:"amount"`
+	CountryCode      string     `json:"countryCode,omitempty"`
+	ExpiresAt        time.Time  `json:"expiresAt"`
+	LineItems        []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount  string     `json:"merchantAccount"`
+	Reference        string     `json:"reference"`
+	ReturnURL        string     `json:"returnUrl"`
+	ShopperLocale    string     `json:"shopperLocale,omitempty"`
+	ShopperReference string     `json:"shopperReference,omitempty"`
+}
+
+// LineItem describes a single order line, amounts are specified in minor units of the payment currency
+//
+// Amounts are per unit, discounts are specified as separate lines with negative amounts.
+// Use ValidateLineItems to check, that lines are consistent with the payment amount
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/sessions__reqParam_lineItems
+type LineItem struct {
+	ID                 string `json:"id,omitempty"`
+	Description        string `json:"description,omitempty"`
+	Quantity           int64  `json:"quantity,omitempty"`
+	AmountExcludingTax int64  `json:"amountExcludingTax,omitempty"`
+	AmountIncludingTax int64  `json:"amountIncludingTax,omitempty"`
+	TaxAmount          int64  `json:"taxAmount,omitempty"`
+	TaxPercentage      int64  `json:"taxPercentage,omitempty"` // In minor units, f.e. 2100 for 21%
+	TaxCategory        string `json:"taxCategory,omitempty"`
+}
+
+/****************
+* Payment links *
+****************/
+
+// Payment link statuses
+//
+// Link - https://docs.adyen.com/unified-commerce/pay-by-link/payment-links/api#payment-link-status
+const (
+	PaymentLinkStatusActive         = "active"
+	PaymentLinkStatusCompleted      = "completed"
+	PaymentLinkStatusExpired        = "expired"
+	PaymentLinkStatusPaid           = "paid"
+	PaymentLinkStatusPaymentPending = "paymentPending"
+)
+
+// CreatePaymentLinkRequest contains the fields required by the checkout
+// API's /paymentLinks endpoint.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/paymentLinks
+type CreatePaymentLinkRequest struct {
+	AllowedPaymentMethods []string   `json:"allowedPaymentMethods,omitempty"`
+	Amount                *Amount    `json:"amount"`
+	BillingAddress        *Address   `json:"billingAddress,omitempty"`
+	BlockedPaymentMethods []string   `json:"blockedPaymentMethods,omitempty"`
+	CountryCode           string     `json:"countryCode,omitempty"`
+	DeliveryAddress       *Address   `json:"deliveryAddress,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	ExpiresAt             *time.Time `json:"expiresAt,omitempty"` // Link expires in 24 hours by default
+	LineItems             []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount       string     `json:"merchantAccount"`
+	Reference             string     `json:"reference"`
+	ReturnURL             string     `json:"returnUrl,omitempty"`
+	ShopperEmail          string     `json:"shopperEmail,omitempty"`
+	ShopperLocale         string     `json:"shopperLocale,omitempty"`
+	ShopperName           *Name      `json:"shopperName,omitempty"`
+	ShopperReference      string     `json:"shopperReference,omitempty"`
+}
+
+// UpdatePaymentLinkRequest contains the fields to update an existing payment link
+//
+// Only PaymentLinkStatusExpired status is currently supported by Adyen, to force link expiration
+type UpdatePaymentLinkRequest struct {
+	Status string `json:"status"`
+}
+
+// PaymentLinkResponse is returned by Adyen in response to
+// a CreatePaymentLink, GetPaymentLink and UpdatePaymentLink requests.
+//
+// URL is the link to be sent to a shopper
+type PaymentLinkResponse struct {
+	ID                    string     `json:"id"`
+	AllowedPaymentMethods []string   `json:"allowedPaymentMethods,omitempty"`
+	Amount                *Amount    `json:"amount"`
+	BlockedPaymentMethods []string   `json:"blockedPaymentMethods,omitempty"`
+	CountryCode           string     `json:"countryCode,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	ExpiresAt             time.Time  `json:"expiresAt"`
+	LineItems             []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount       string     `json:"merchantAccount"`
+	Reference             string     `json:"reference"`
+	ReturnURL             string     `json:"returnUrl,omitempty"`
+	ShopperEmail          string     `json:"shopperEmail,omitempty"`
+	ShopperLocale         string     `json:"shopperLocale,omitempty"`
+	ShopperReference      string     `json:"shopperReference,omitempty"`
+	Status                string     `json:"status"`
+	URL                   string     `json:"url"`
+}
+
+/****************
+* Modifications *
+****************/
+
+// ModificationStatusReceived - status of a modification resource, accepted by Adyen for processing.
+// Modification result is sent with a notification
+const ModificationStatusReceived = "received"
+
+// PaymentCaptureRequest contains the fields required by the checkout
+// API's /payments/{paymentPspReference}/captures endpoint.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/payments/{paymentPspReference}/captures
+type PaymentCaptureRequest struct {
+	Amount          *Amount    `json:"amount"`
+	LineItems       []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount string     `json:"merchantAccount"`
+	Reference       string     `json:"reference,omitempty"`
+}
+
+// PaymentCaptureResource is returned by Adyen in response to
+// a CapturePayment request.
+type PaymentCaptureResource struct {
+	Amount              *Amount    `json:"amount"`
+	LineItems           []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount     string     `json:"merchantAccount"`
+	PaymentPspReference string     `json:"paymentPspReference"`
+	PspReference        string     `json:"pspReference"`
+	Reference           string     `json:"reference,omitempty"`
+	Status              string     `json:"status"`
+}
+
+// PaymentRefundRequest contains the fields required by the checkout
+// API's /payments/{paymentPspReference}/refunds endpoint.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/payments/{paymentPspReference}/refunds
+type PaymentRefundRequest struct {
+	Amount          *Amount    `json:"amount"`
+	LineItems       []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount string     `json:"merchantAccount"`
+	Reference       string     `json:"reference,omitempty"`
+}
+
+// PaymentRefundResource is returned by Adyen in response to
+// a RefundPayment request.
+type PaymentRefundResource struct {
+	Amount              *Amount    `json:"amount"`
+	LineItems           []LineItem `json:"lineItems,omitempty"`
+	MerchantAccount     string     `json:"merchantAccount"`
+	PaymentPspReference string     `json:"paymentPspReference"`
+	PspReference        string     `json:"pspReference"`
+	Reference           string     `json:"reference,omitempty"`
+	Status              string     `json:"status"`
+}
+
+// PaymentCancelRequest contains the fields required by the checkout
+// API's /payments/{paymentPspReference}/cancels endpoint.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/payments/{paymentPspReference}/cancels
+type PaymentCancelRequest struct {
+	MerchantAccount string `json:"merchantAccount"`
+	Reference       string `json:"reference,omitempty"`
+}
+
+// PaymentCancelResource is returned by Adyen in response to
+// a CancelPayment request.
+type PaymentCancelResource struct {
+	MerchantAccount     string `json:"merchantAccount"`
+	PaymentPspReference string `json:"paymentPspReference"`
+	PspReference        string `json:"pspReference"`
+	Reference           string `json:"reference,omitempty"`
+	Status              string `json:"status"`
+}
+
+// PaymentReversalRequest contains the fields required by the checkout
+// API's /payments/{paymentPspReference}/reversals endpoint.
+//
+// Payment is cancelled if not captured yet, otherwise it's refunded.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/payments/{paymentPspReference}/reversals
+type PaymentReversalRequest struct {
+	MerchantAccount string `json:"merchantAccount"`
+	Reference       string `json:"reference,omitempty"`
+}
+
+// PaymentReversalResource is returned by Adyen in response to
+// a ReversePayment request.
+type PaymentReversalResource struct {
+	MerchantAccount     string `json:"merchantAccount"`
+	PaymentPspReference string `json:"paymentPspReference"`
+	PspReference        string `json:"pspReference"`
+	Reference           string `json:"reference,omitempty"`
+	Status              string `json:"status"`
+}
+
+// PaymentAmountUpdateRequest contains the fields required by the checkout
+// API's /payments/{paymentPspReference}/amountUpdates endpoint.
+//
+// IndustryUsage is one of DelayedCharge or NoShow reasons.
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/payments/{paymentPspReference}/amountUpdates
+type PaymentAmountUpdateRequest struct {
+	Amount          *Amount `json:"amount"`
+	IndustryUsage   string  `json:"industryUsage,omitempty"`
+	MerchantAccount string  `json:"merchantAccount"`
+	Reference       string  `json:"reference,omitempty"`
+}
+
+// PaymentAmountUpdateResource is returned by Adyen in response to
+// a UpdatePaymentAmount request.
+type PaymentAmountUpdateResource struct {
+	Amount              *Amount `json:"amount"`
+	IndustryUsage       string  `json:"industryUsage,omitempty"`
+	MerchantAccount     string  `json:"merchantAccount"`
+	PaymentPspReference string  `json:"paymentPspReference"`
+	PspReference        string  `json:"pspReference"`
+	Reference           string  `json:"reference,omitempty"`
+	Status              string  `json:"status"`
+}
@@ -1,5 +1,11 @@
 package adyen
 
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
 // CheckoutGateway - allows you to accept all of Adyen's payment
 // methods and flows.
 type CheckoutGateway struct {
@@ -8,18 +14,252 @@ type CheckoutGateway struct {
 
 const (
 	paymentMethodsURL = "paymentMethods"
+	paymentsURL       = "payments"
+	paymentDetailsURL = "payments/details"
+	sessionsURL       = "sessions"
+	paymentLinksURL   = "paymentLinks"
+
+	// payment modifications, performed on payments/{paymentPspReference} resource
+	capturesURL      = "captures"
+	refundsURL       = "refunds"
+	cancelsURL       = "cancels"
+	reversalsURL     = "reversals"
+	amountUpdatesURL = "amountUpdates"
 )
 
 // PaymentMethods - Perform paymentMethods request in Adyen.
 //
 // Used to get a collection of available payment methods for a merchant.
 func (a *CheckoutGateway) PaymentMethods(req *PaymentMethods) (*PaymentMethodsResponse, error) {
+	return a.PaymentMethodsContext(context.Background(), req)
+}
+
+// PaymentMethodsContext - Perform paymentMethods request in Adyen, bound to a given context
+func (a *CheckoutGateway) PaymentMethodsContext(ctx context.Context, req *PaymentMethods) (*PaymentMethodsResponse, error) {
 	url := a.checkoutURL(paymentMethodsURL, CheckoutAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 	if err != nil {
 		return nil, err
 	}
 
 	return resp.paymentMethods()
 }
+
+// Payments - Perform payments request in Adyen.
+//
+// Used to make a payment with payment method details collected by Drop-in or Components.
+// If ResultCode requires further shopper interaction, response Action need to be handled and
+// results submitted with PaymentDetails.
+func (a *CheckoutGateway) Payments(req *PaymentsRequest) (*PaymentsResponse, error) {
+	return a.PaymentsContext(context.Background(), req)
+}
+
+// PaymentsContext - Perform payments request in Adyen, bound to a given context
+func (a *CheckoutGateway) PaymentsContext(ctx context.Context, req *PaymentsRequest) (*PaymentsResponse, error) {
+	if err := req.ValidateRecurring(); err != nil {
+		return nil, err
+	}
+
+	url := a.checkoutURL(paymentsURL, CheckoutAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.payments()
+}
+
+// PaymentDetails - Perform payments/details request in Adyen.
+//
+// Used to submit additional details, f.e. redirect or 3D Secure 2 results, to complete a payment.
+func (a *CheckoutGateway) PaymentDetails(req *PaymentDetailsRequest) (*PaymentDetailsResponse, error) {
+	return a.PaymentDetailsContext(context.Background(), req)
+}
+
+// PaymentDetailsContext - Perform payments/details request in Adyen, bound to a given context
+func (a *CheckoutGateway) PaymentDetailsContext(ctx context.Context, req *PaymentDetailsRequest) (*PaymentDetailsResponse, error) {
+	url := a.checkoutURL(paymentDetailsURL, CheckoutAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentDetails()
+}
+
+// Sessions - Perform sessions request in Adyen.
+//
+// Used to create a payment session for session based Drop-in or Components flow,
+// returned ID and SessionData need to be passed to the frontend.
+func (a *CheckoutGateway) Sessions(req *CreateCheckoutSessionRequest) (*CreateCheckoutSessionResponse, error) {
+	return a.SessionsContext(context.Background(), req)
+}
+
+// SessionsContext - Perform sessions request in Adyen, bound to a given context
+func (a *CheckoutGateway) SessionsContext(ctx context.Context, req *CreateCheckoutSessionRequest) (*CreateCheckoutSessionResponse, error) {
+	if err := req.ValidateRecurring(); err != nil {
+		return nil, err
+	}
+
+	url := a.checkoutURL(sessionsURL, CheckoutSessionsAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.sessions()
+}
+
+// CreatePaymentLink - Perform paymentLinks request in Adyen.
+//
+// Used to create a payment link, that could be sent to a shopper, f.e. by email
+func (a *CheckoutGateway) CreatePaymentLink(req *CreatePaymentLinkRequest) (*PaymentLinkResponse, error) {
+	return a.CreatePaymentLinkContext(context.Background(), req)
+}
+
+// CreatePaymentLinkContext - Perform paymentLinks request in Adyen, bound to a given context
+func (a *CheckoutGateway) CreatePaymentLinkContext(ctx context.Context, req *CreatePaymentLinkRequest) (*PaymentLinkResponse, error) {
+	url := a.checkoutURL(paymentLinksURL, CheckoutPaymentLinksAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentLink()
+}
+
+// GetPaymentLink - Retrieve payment link details and status from Adyen
+func (a *CheckoutGateway) GetPaymentLink(linkID string) (*PaymentLinkResponse, error) {
+	return a.GetPaymentLinkContext(context.Background(), linkID)
+}
+
+// GetPaymentLinkContext - Retrieve payment link details and status from Adyen, bound to a given context
+func (a *CheckoutGateway) GetPaymentLinkContext(ctx context.Context, linkID string) (*PaymentLinkResponse, error) {
+	resp, err := a.executeMethod(ctx, http.MethodGet, a.paymentLinkURL(linkID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentLink()
+}
+
+// UpdatePaymentLink - Update payment link in Adyen
+//
+// Used to force payment link expiration:
+//
+//	&adyen.UpdatePaymentLinkRequest{Status: adyen.PaymentLinkStatusExpired}
+func (a *CheckoutGateway) UpdatePaymentLink(linkID string, req *UpdatePaymentLinkRequest) (*PaymentLinkResponse, error) {
+	return a.UpdatePaymentLinkContext(context.Background(), linkID, req)
+}
+
+// UpdatePaymentLinkContext - Update payment link in Adyen, bound to a given context
+func (a *CheckoutGateway) UpdatePaymentLinkContext(ctx context.Context, linkID string, req *UpdatePaymentLinkRequest) (*PaymentLinkResponse, error) {
+	resp, err := a.executeMethod(ctx, http.MethodPatch, a.paymentLinkURL(linkID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentLink()
+}
+
+// paymentLinkURL returns the URL of a single payment link
+func (a *CheckoutGateway) paymentLinkURL(linkID string) string {
+	return a.checkoutURL(paymentLinksURL+"/"+url.PathEscape(linkID), CheckoutPaymentLinksAPIVersion)
+}
+
+// CapturePayment - Perform capture of the authorised payment in Adyen
+//
+// Modification is processed asynchronously, resource with ModificationStatusReceived status is returned
+func (a *CheckoutGateway) CapturePayment(pspReference string, req *PaymentCaptureRequest) (*PaymentCaptureResource, error) {
+	return a.CapturePaymentContext(context.Background(), pspReference, req)
+}
+
+// CapturePaymentContext - Perform capture of the authorised payment in Adyen, bound to a given context
+func (a *CheckoutGateway) CapturePaymentContext(ctx context.Context, pspReference string, req *PaymentCaptureRequest) (*PaymentCaptureResource, error) {
+	resp, err := a.execute(ctx, a.paymentModificationURL(pspReference, capturesURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentCapture()
+}
+
+// RefundPayment - Perform refund of the captured payment in Adyen
+//
+// Modification is processed asynchronously, resource with ModificationStatusReceived status is returned
+func (a *CheckoutGateway) RefundPayment(pspReference string, req *PaymentRefundRequest) (*PaymentRefundResource, error) {
+	return a.RefundPaymentContext(context.Background(), pspReference, req)
+}
+
+// RefundPaymentContext - Perform refund of the captured payment in Adyen, bound to a given context
+func (a *CheckoutGateway) RefundPaymentContext(ctx context.Context, pspReference string, req *PaymentRefundRequest) (*PaymentRefundResource, error) {
+	resp, err := a.execute(ctx, a.paymentModificationURL(pspReference, refundsURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentRefund()
+}
+
+// CancelPayment - Perform cancellation of the authorised payment in Adyen
+//
+// Modification is processed asynchronously, resource with ModificationStatusReceived status is returned
+func (a *CheckoutGateway) CancelPayment(pspReference string, req *PaymentCancelRequest) (*PaymentCancelResource, error) {
+	return a.CancelPaymentContext(context.Background(), pspReference, req)
+}
+
+// CancelPaymentContext - Perform cancellation of the authorised payment in Adyen, bound to a given context
+func (a *CheckoutGateway) CancelPaymentContext(ctx context.Context, pspReference string, req *PaymentCancelRequest) (*PaymentCancelResource, error) {
+	resp, err := a.execute(ctx, a.paymentModificationURL(pspReference, cancelsURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentCancel()
+}
+
+// ReversePayment - Perform reversal of the payment in Adyen
+//
+// Payment is cancelled if not captured yet, otherwise it's refunded.
+//
+// Modification is processed asynchronously, resource with ModificationStatusReceived status is returned
+func (a *CheckoutGateway) ReversePayment(pspReference string, req *PaymentReversalRequest) (*PaymentReversalResource, error) {
+	return a.ReversePaymentContext(context.Background(), pspReference, req)
+}
+
+// ReversePaymentContext - Perform reversal of the payment in Adyen, bound to a given context
+func (a *CheckoutGateway) ReversePaymentContext(ctx context.Context, pspReference string, req *PaymentReversalRequest) (*PaymentReversalResource, error) {
+	resp, err := a.execute(ctx, a.paymentModificationURL(pspReference, reversalsURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentReversal()
+}
+
+// UpdatePaymentAmount - Perform update of the authorised amount in Adyen
+//
+// Modification is processed asynchronously, resource with ModificationStatusReceived status is returned
+func (a *CheckoutGateway) UpdatePaymentAmount(pspReference string, req *PaymentAmountUpdateRequest) (*PaymentAmountUpdateResource, error) {
+	return a.UpdatePaymentAmountContext(context.Background(), pspReference, req)
+}
+
+// UpdatePaymentAmountContext - Perform update of the authorised amount in Adyen, bound to a given context
+func (a *CheckoutGateway) UpdatePaymentAmountContext(ctx context.Context, pspReference string, req *PaymentAmountUpdateRequest) (*PaymentAmountUpdateResource, error) {
+	resp, err := a.execute(ctx, a.paymentModificationURL(pspReference, amountUpdatesURL), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.paymentAmountUpdate()
+}
+
+// paymentModificationURL returns the URL of a modification for a given payment
+func (a *CheckoutGateway) paymentModificationURL(pspReference, modification string) string {
+	return a.checkoutURL(paymentsURL+"/"+url.PathEscape(pspReference)+"/"+modification, CheckoutModificationsAPIVersion)
+}
@@ -1,8 +1,14 @@
 package adyen
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 // TestPaymentMethods - test for https://docs.adyen.com/developers/checkout/api-integration
@@ -28,3 +34,255 @@ func TestPaymentMethods(t *testing.T) {
 		t.Errorf("Response should be succesfull, error - %s", err.Error())
 	}
 }
+
+func TestCheckoutPaymentsFlow(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	var details PaymentDetailsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/" + CheckoutAPIVersion + "/payments":
+			fmt.Fprint(w, `{"resultCode":"RedirectShopper","action":{"method":"GET","paymentMethodType":"ideal","type":"redirect","url":"https://test.adyen.com/hpp/redirectIdeal.shtml"},"paymentData":"Ab02b4c0!BQABAgA..."}`)
+		case "/" + CheckoutAPIVersion + "/payments/details":
+			if err := json.NewDecoder(r.Body).Decode(&details); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"pspReference":"8535296650153317","resultCode":"Authorised","merchantReference":"DE-TEST-1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	res, err := instance.Checkout().Payments(&PaymentsRequest{
+		Amount:          &Amount{Value: 1000, Currency: "EUR"},
+		MerchantAccount: "TestMerchant",
+		Reference:       "DE-TEST-1",
+		ReturnURL:       "https://your-company.com/checkout",
+		PaymentMethod:   &CheckoutPaymentMethod{Type: "ideal", Issuer: "1121"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, ResultCodeRedirectShopper, res.ResultCode)
+	equals(t, ActionTypeRedirect, res.Action.Type)
+
+	detailsRes, err := instance.Checkout().PaymentDetails(&PaymentDetailsRequest{
+		Details:     map[string]string{"payload": "Ab02b4c0!BQABAgCW5sxB4e..."},
+		PaymentData: res.PaymentData,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, []string{"/" + CheckoutAPIVersion + "/payments", "/" + CheckoutAPIVersion + "/payments/details"}, paths)
+	equals(t, "Ab02b4c0!BQABAgA...", details.PaymentData)
+	equals(t, ResultCodeAuthorised, detailsRes.ResultCode)
+	equals(t, "8535296650153317", detailsRes.PspReference)
+}
+
+func TestCheckoutSessions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path string
+		req  CreateCheckoutSessionRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"amount":{"currency":"EUR","value":1000},"expiresAt":"2021-08-24T13:35:16+02:00","id":"CSD9CAC34EBAE225DD","merchantAccount":"TestMerchant","reference":"DE-TEST-1","returnUrl":"https://your-company.com/checkout","sessionData":"Ab02b4c..."}`)
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	expiresAt := time.Date(2021, 8, 24, 13, 35, 16, 0, time.FixedZone("CEST", 2*60*60))
+	res, err := instance.Checkout().Sessions(&CreateCheckoutSessionRequest{
+		Amount:             &Amount{Value: 1000, Currency: "EUR"},
+		MerchantAccount:    "TestMerchant",
+		Reference:          "DE-TEST-1",
+		ReturnURL:          "https://your-company.com/checkout",
+		ExpiresAt:          &expiresAt,
+		ShopperReference:   "unique-customer-reference",
+		StorePaymentMethod: true,
+		LineItems: []LineItem{
+			{ID: "1", Description: "Shoes", Quantity: 1, AmountIncludingTax: 1000},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/v68/sessions", path)
+	equals(t, "unique-customer-reference", req.ShopperReference)
+	equals(t, true, req.StorePaymentMethod)
+	equals(t, 1, len(req.LineItems))
+	assert(t, req.ExpiresAt.Equal(expiresAt), "Session expiration date should be sent to Adyen")
+
+	equals(t, "CSD9CAC34EBAE225DD", res.ID)
+	equals(t, "Ab02b4c...", res.SessionData)
+	assert(t, res.ExpiresAt.Equal(expiresAt), "Session expiration date should be parsed")
+}
+
+func TestCheckoutPaymentLinks(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   string
+	}
+
+	var calls []call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(bytes.Buffer)
+		if _, err := body.ReadFrom(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		calls = append(calls, call{method: r.Method, path: r.URL.Path, body: body.String()})
+
+		status := PaymentLinkStatusActive
+		if r.Method == http.MethodPatch {
+			status = PaymentLinkStatusExpired
+		}
+		fmt.Fprintf(w, `{"amount":{"currency":"EUR","value":4200},"expiresAt":"2020-10-29T15:32:59Z","reference":"DE-TEST-1","merchantAccount":"TestMerchant","id":"PL50C5F751CED39G71","status":"%s","url":"https://test.adyen.link/PL50C5F751CED39G71","allowedPaymentMethods":["ideal","scheme"]}`, status)
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	created, err := instance.Checkout().CreatePaymentLink(&CreatePaymentLinkRequest{
+		Amount:                &Amount{Value: 4200, Currency: "EUR"},
+		MerchantAccount:       "TestMerchant",
+		Reference:             "DE-TEST-1",
+		AllowedPaymentMethods: []string{"ideal", "scheme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "PL50C5F751CED39G71", created.ID)
+	equals(t, "https://test.adyen.link/PL50C5F751CED39G71", created.URL)
+	equals(t, PaymentLinkStatusActive, created.Status)
+	equals(t, []string{"ideal", "scheme"}, created.AllowedPaymentMethods)
+	equals(t, time.Date(2020, 10, 29, 15, 32, 59, 0, time.UTC), created.ExpiresAt)
+
+	if _, err = instance.Checkout().GetPaymentLink(created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	expired, err := instance.Checkout().UpdatePaymentLink(created.ID, &UpdatePaymentLinkRequest{Status: PaymentLinkStatusExpired})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, PaymentLinkStatusExpired, expired.Status)
+
+	linkPath := "/v66/paymentLinks/PL50C5F751CED39G71"
+	equals(t, []call{
+		{method: http.MethodPost, path: "/v66/paymentLinks", body: `{"allowedPaymentMethods":["ideal","scheme"],"amount":{"value":4200,"currency":"EUR"},"merchantAccount":"TestMerchant","reference":"DE-TEST-1"}`},
+		{method: http.MethodGet, path: linkPath, body: ""},
+		{method: http.MethodPatch, path: linkPath, body: `{"status":"expired"}`},
+	}, calls)
+}
+
+func TestCheckoutPaymentLinkURL(t *testing.T) {
+	env, err := ProductionEnvironment("5409c4fd1cc98a4e", "AcmeAccount123")
+	if err != nil {
+		t.Fatalf("error creating production environment: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		env  Environment
+		exp  string
+	}{
+		{
+			name: "testing environment",
+			env:  Testing,
+			exp:  "https://checkout-test.adyen.com/services/PaymentSetupAndVerification/v66/paymentLinks/PL50C5F751CED39G71",
+		},
+		{
+			name: "production environment",
+			env:  env,
+			exp:  "https://5409c4fd1cc98a4e-AcmeAccount123-checkout-live.adyenpayments.com/services/PaymentSetupAndVerification/v66/paymentLinks/PL50C5F751CED39G71",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			instance := New(c.env, "username", "password")
+			equals(t, c.exp, instance.Checkout().paymentLinkURL("PL50C5F751CED39G71"))
+		})
+	}
+}
+
+func TestCheckoutPaymentModifications(t *testing.T) {
+	t.Parallel()
+
+	const pspReference = "993617895204576J"
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprintf(w, `{"merchantAccount":"TestMerchant","paymentPspReference":"%s","pspReference":"863620292981235A","reference":"DE-TEST-1","status":"received","amount":{"currency":"EUR","value":500}}`, pspReference)
+	}))
+	defer srv.Close()
+
+	checkout := getTestInstanceWithServer(srv).Checkout()
+	amount := &Amount{Value: 500, Currency: "EUR"}
+
+	capture, err := checkout.CapturePayment(pspReference, &PaymentCaptureRequest{Amount: amount, MerchantAccount: "TestMerchant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, ModificationStatusReceived, capture.Status)
+	equals(t, pspReference, capture.PaymentPspReference)
+	equals(t, "863620292981235A", capture.PspReference)
+	equals(t, amount, capture.Amount)
+
+	refund, err := checkout.RefundPayment(pspReference, &PaymentRefundRequest{Amount: amount, MerchantAccount: "TestMerchant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, ModificationStatusReceived, refund.Status)
+
+	cancel, err := checkout.CancelPayment(pspReference, &PaymentCancelRequest{MerchantAccount: "TestMerchant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, ModificationStatusReceived, cancel.Status)
+
+	reversal, err := checkout.ReversePayment(pspReference, &PaymentReversalRequest{MerchantAccount: "TestMerchant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, ModificationStatusReceived, reversal.Status)
+
+	update, err := checkout.UpdatePaymentAmount(pspReference, &PaymentAmountUpdateRequest{Amount: amount, MerchantAccount: "TestMerchant", IndustryUsage: DelayedCharge})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, ModificationStatusReceived, update.Status)
+
+	base := "/v67/payments/" + pspReference
+	equals(t, []string{
+		base + "/captures",
+		base + "/refunds",
+		base + "/cancels",
+		base + "/reversals",
+		base + "/amountUpdates",
+	}, paths)
+}
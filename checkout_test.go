@@ -238,3 +238,73 @@ func TestPaymentMethodsResponse_ParseOneClick(t *testing.T) {
 
 	equals(t, exp, response)
 }
+
+func TestPaymentsResponse_ParseRedirectAction(t *testing.T) {
+	rawResponse := `{"resultCode":"RedirectShopper","action":{"data":{"MD":"OEVudmZVMUlkWjd0MDNwUWs2bmhSdz09...","PaReq":"eNpVUttygjAQ/RXbDyAXBYRZ07HiVNsBaaUvfa...","TermUrl":"https://your-company.com/checkout?shopperOrder=12xy.."},"method":"POST","paymentData":"Ab02b4c0!BQABAgCJN1wRZuGJmq8dMncmypvknj9s7l5Tj...","paymentMethodType":"scheme","type":"redirect","url":"https://test.adyen.com/hpp/3d/validate.shtml"},"details":[{"key":"MD","type":"text"},{"key":"PaRes","type":"text"}],"paymentData":"Ab02b4c0!BQABAgCJN1wRZuGJmq8dMncmypvknj9s7l5Tj..."}`
+
+	var response PaymentsResponse
+	if err := json.Unmarshal([]byte(rawResponse), &response); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	exp := PaymentsResponse{
+		ResultCode: ResultCodeRedirectShopper,
+		Action: &Action{
+			Type:              ActionTypeRedirect,
+			PaymentMethodType: "scheme",
+			PaymentData:       "Ab02b4c0!BQABAgCJN1wRZuGJmq8dMncmypvknj9s7l5Tj...",
+			URL:               "https://test.adyen.com/hpp/3d/validate.shtml",
+			Method:            "POST",
+			Data: map[string]string{
+				"MD":      "OEVudmZVMUlkWjd0MDNwUWs2bmhSdz09...",
+				"PaReq":   "eNpVUttygjAQ/RXbDyAXBYRZ07HiVNsBaaUvfa...",
+				"TermUrl": "https://your-company.com/checkout?shopperOrder=12xy..",
+			},
+		},
+		Details: []PaymentMethodTypes{
+			{Key: "MD", Type: "text"},
+			{Key: "PaRes", Type: "text"},
+		},
+		PaymentData: "Ab02b4c0!BQABAgCJN1wRZuGJmq8dMncmypvknj9s7l5Tj...",
+	}
+
+	equals(t, exp, response)
+}
+
+func TestPaymentsResponse_ParseThreeDS2Action(t *testing.T) {
+	rawResponse := `{"resultCode":"IdentifyShopper","action":{"paymentData":"Ab02b4c0!BQABAgCuZFJrQOjSsl\/zt+...","paymentMethodType":"scheme","token":"eyJ0aHJlZURTTWV0aG9kTm90aWZpY...","type":"threeDS2Fingerprint"},"authentication":{"threeds2.fingerprintToken":"eyJ0aHJlZURTTWV0aG9kTm90aWZpY..."},"details":[{"key":"threeds2.fingerprint","type":"text"}],"paymentData":"Ab02b4c0!BQABAgCuZFJrQOjSsl\/zt+..."}`
+
+	var response PaymentsResponse
+	if err := json.Unmarshal([]byte(rawResponse), &response); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	equals(t, ResultCodeIdentifyShopper, response.ResultCode)
+	equals(t, ActionTypeThreeDS2Fingerprint, response.Action.Type)
+	equals(t, "eyJ0aHJlZURTTWV0aG9kTm90aWZpY...", response.Action.Token)
+	equals(t, "Ab02b4c0!BQABAgCuZFJrQOjSsl/zt+...", response.PaymentData)
+}
+
+func TestPaymentsRequest_Marshal(t *testing.T) {
+	req := PaymentsRequest{
+		Amount:          &Amount{Value: 1000, Currency: "EUR"},
+		MerchantAccount: "TestMerchant",
+		Reference:       "DE-TEST-1",
+		ReturnURL:       "https://your-company.com/checkout?shopperOrder=12xy",
+		PaymentMethod: &CheckoutPaymentMethod{
+			Type:                  "scheme",
+			EncryptedCardNumber:   "test_4111111111111111",
+			EncryptedExpiryMonth:  "test_03",
+			EncryptedExpiryYear:   "test_2030",
+			EncryptedSecurityCode: "test_737",
+		},
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `{"amount":{"value":1000,"currency":"EUR"},"merchantAccount":"TestMerchant","paymentMethod":{"type":"scheme","encryptedCardNumber":"test_4111111111111111","encryptedExpiryMonth":"test_03","encryptedExpiryYear":"test_2030","encryptedSecurityCode":"test_737"},"reference":"DE-TEST-1","returnUrl":"https://your-company.com/checkout?shopperOrder=12xy"}`
+	equals(t, exp, string(b))
+}
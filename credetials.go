@@ -1,5 +1,13 @@
 package adyen
 
+import (
+	"net/http"
+	"time"
+)
+
+// apiKeyHeader - header to pass web service API key to Adyen
+const apiKeyHeader = "X-API-Key"
+
 // apiCredentials basic API settings
 //
 // Description:
@@ -7,7 +15,9 @@ package adyen
 //     - Env - Environment for next API calls
 //     - Username - API username for authentication
 //     - Password - API password for authentication
-//     - Hmac - Hash-based Message Authentication Code (HMAC) setting
+//     - Hmac - Hash-based Message Authentication Code (HMAC) setting, primary key to sign and validate signatures
+//     - APIKey - web service API key, sent instead of Username and Password if specified
+//     - VerificationKeys - additional HMAC keys to validate signatures, f.e. during key rotation
 //
 // You can create new API user there: https://ca-test.adyen.com/ca/ca/config/users.shtml
 // New skin can be created there https://ca-test.adyen.com/ca/ca/skin/skins.shtml
@@ -16,6 +26,31 @@ type apiCredentials struct {
 	Username string
 	Password string
 	Hmac     string
+	APIKey   string
+
+	VerificationKeys []HMACKey
+}
+
+// HMACKey - additional HMAC key, accepted to validate signatures within a validity window
+//
+// Zero NotBefore or NotAfter means the window is not limited from that side
+type HMACKey struct {
+	Key       string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// ValidAt - checks if key is valid at a given time
+func (k HMACKey) ValidAt(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+
+	if !k.NotAfter.IsZero() && t.After(k.NotAfter) {
+		return false
+	}
+
+	return true
 }
 
 // makeCredentials create new APICredentials
@@ -36,3 +71,39 @@ func makeCredentialsWithHMAC(env Environment, username, password, hmac string) a
 		Hmac:     hmac,
 	}
 }
+
+// makeCredentialsWithAPIKey create new APICredentials with web service API key
+func makeCredentialsWithAPIKey(env Environment, apiKey string) apiCredentials {
+	return apiCredentials{
+		Env:    env,
+		APIKey: apiKey,
+	}
+}
+
+// authorize - add authentication data to request
+//
+// API key authentication is used if APIKey is specified, basic authentication otherwise
+func (c apiCredentials) authorize(req *http.Request) {
+	if c.APIKey != "" {
+		req.Header.Set(apiKeyHeader, c.APIKey)
+		return
+	}
+
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// hmacKeys - returns primary HMAC key and all verification keys valid at a given time
+func (c apiCredentials) hmacKeys(at time.Time) []string {
+	keys := make([]string, 0, len(c.VerificationKeys)+1)
+	if c.Hmac != "" {
+		keys = append(keys, c.Hmac)
+	}
+
+	for _, k := range c.VerificationKeys {
+		if k.Key != "" && k.ValidAt(at) {
+			keys = append(keys, k.Key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,187 @@
+package adyen
+
+import "testing"
+
+func TestLookupCurrency(t *testing.T) {
+	cases := []struct {
+		name        string
+		code        string
+		exp         Currency
+		expDecimals uint
+		expOk       bool
+	}{
+		{
+			name:        "Test EUR currency",
+			code:        "EUR",
+			exp:         Currency{Code: "EUR", Numeric: "978", MinorUnits: 2},
+			expDecimals: 2,
+			expOk:       true,
+		},
+		{
+			name:        "Test IDR currency, Adyen expects no decimals",
+			code:        "IDR",
+			exp:         Currency{Code: "IDR", Numeric: "360", MinorUnits: 2},
+			expDecimals: 0,
+			expOk:       true,
+		},
+		{
+			name:        "Test CLP currency, Adyen expects 2 decimals",
+			code:        "CLP",
+			exp:         Currency{Code: "CLP", Numeric: "152", MinorUnits: 0},
+			expDecimals: 2,
+			expOk:       true,
+		},
+		{
+			name:        "Test KWD currency with 3 decimals",
+			code:        "KWD",
+			exp:         Currency{Code: "KWD", Numeric: "414", MinorUnits: 3},
+			expDecimals: 3,
+			expOk:       true,
+		},
+		{
+			name:        "Test ISK currency, Adyen expects 2 decimals",
+			code:        "ISK",
+			exp:         Currency{Code: "ISK", Numeric: "352", MinorUnits: 0},
+			expDecimals: 2,
+			expOk:       true,
+		},
+		{
+			name:        "Test BIF currency without decimals",
+			code:        "BIF",
+			exp:         Currency{Code: "BIF", Numeric: "108", MinorUnits: 0},
+			expDecimals: 0,
+			expOk:       true,
+		},
+		{
+			name:        "Test IQD currency with 3 decimals",
+			code:        "IQD",
+			exp:         Currency{Code: "IQD", Numeric: "368", MinorUnits: 3},
+			expDecimals: 3,
+			expOk:       true,
+		},
+		{
+			name:        "Test CLF currency with 4 decimals",
+			code:        "CLF",
+			exp:         Currency{Code: "CLF", Numeric: "990", MinorUnits: 4},
+			expDecimals: 4,
+			expOk:       true,
+		},
+		{
+			name:  "Test unknown currency",
+			code:  "UKN",
+			expOk: false,
+		},
+		{
+			name:  "Test lower case currency",
+			code:  "eur",
+			expOk: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			currency, ok := LookupCurrency(c.code)
+			equals(t, c.expOk, ok)
+			equals(t, c.exp, currency)
+			equals(t, c.expOk, ValidateCurrency(c.code) == nil)
+
+			if ok {
+				equals(t, c.expDecimals, currency.Decimals())
+			}
+		})
+	}
+}
+
+func TestCurrenciesTable(t *testing.T) {
+	numeric := make(map[string]string, len(Currencies))
+
+	for code, c := range Currencies {
+		equals(t, code, c.Code)
+		assert(t, len(c.Numeric) == 3, "Numeric code should have 3 digits: "+code)
+
+		other, ok := numeric[c.Numeric]
+		assert(t, !ok, "Numeric code "+c.Numeric+" is used by both "+code+" and "+other)
+		numeric[c.Numeric] = code
+	}
+
+	for code := range AdyenCurrencyDecimals {
+		_, ok := Currencies[code]
+		assert(t, ok, "Adyen currency "+code+" should be in ISO 4217 table")
+	}
+
+	// decimals of deprecated CurrencyDecimals map are kept
+	for code, decimals := range CurrencyDecimals {
+		equals(t, decimals, currencyDecimals(code))
+	}
+}
+
+func TestAmount_Format(t *testing.T) {
+	cases := []struct {
+		amount    Amount
+		expFormat string
+		expString string
+	}{
+		{Amount{Currency: "EUR", Value: 1050}, "10.50", "10.50 EUR"},
+		{Amount{Currency: "EUR", Value: 5}, "0.05", "0.05 EUR"},
+		{Amount{Currency: "EUR", Value: 0}, "0.00", "0.00 EUR"},
+		{Amount{Currency: "EUR", Value: -1050}, "-10.50", "-10.50 EUR"},
+		{Amount{Currency: "JPY", Value: 1050}, "1050", "1050 JPY"},
+		{Amount{Currency: "BHD", Value: 150050}, "150.050", "150.050 BHD"},
+		{Amount{Currency: "IDR", Value: 123456789012}, "123456789012", "123456789012 IDR"},
+		{Amount{Currency: "UYW", Value: 12345}, "1.2345", "1.2345 UYW"},
+		{Amount{Currency: "BIF", Value: 1050}, "1050", "1050 BIF"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expString, func(t *testing.T) {
+			equals(t, c.expFormat, c.amount.Format())
+			equals(t, c.expString, c.amount.String())
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		name     string
+		currency string
+		input    string
+		expected *Amount
+		expErr   bool
+	}{
+		{"plain", "EUR", "10.50", &Amount{Currency: "EUR", Value: 1050}, false},
+		{"comma decimal separator", "EUR", "10,50", &Amount{Currency: "EUR", Value: 1050}, false},
+		{"spaces and currency prefix", "EUR", " EUR 10.50 ", &Amount{Currency: "EUR", Value: 1050}, false},
+		{"currency suffix", "EUR", "10.50 EUR", &Amount{Currency: "EUR", Value: 1050}, false},
+		{"grouping with comma", "EUR", "1,234.50", &Amount{Currency: "EUR", Value: 123450}, false},
+		{"grouping with dot", "EUR", "1.234,50", &Amount{Currency: "EUR", Value: 123450}, false},
+		{"grouping with space", "EUR", "1 234,5", &Amount{Currency: "EUR", Value: 123450}, false},
+		{"repeated grouping", "EUR", "1,234,567", &Amount{Currency: "EUR", Value: 123456700}, false},
+		{"ambiguous grouping", "EUR", "1,234", nil, true},
+		{"ambiguous decimals", "EUR", "10.505", nil, true},
+		{"too many decimals", "EUR", "10.5055", nil, true},
+		{"decimal separator in grouping", "EUR", "1.234.50", nil, true},
+		{"mixed grouping separators", "EUR", "1,234 567.50", nil, true},
+		{"short group", "EUR", "1,23,456.50", nil, true},
+		{"long first group", "EUR", "1234,567.50", nil, true},
+		{"decimals for zero decimals currency", "JPY", "1.5", nil, true},
+		{"decimals with integer for zero decimals currency", "JPY", "10.5", nil, true},
+		{"decimals for 3 decimals currency", "BHD", "1,234", &Amount{Currency: "BHD", Value: 1234}, false},
+		{"grouping for zero decimals currency", "JPY", "1,234", &Amount{Currency: "JPY", Value: 1234}, false},
+		{"grouping for IDR", "IDR", "25.000.000", &Amount{Currency: "IDR", Value: 25000000}, false},
+		{"decimals for 3 decimals ISO currency", "IQD", "1,234", &Amount{Currency: "IQD", Value: 1234}, false},
+		{"unknown currency", "UKN", "10.50", nil, true},
+		{"letters", "EUR", "ten", nil, true},
+		{"empty", "EUR", "", nil, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, err := ParseAmount(c.currency, c.input)
+			if (err != nil) != c.expErr {
+				t.Fatalf("expected error?: %t, actual error: %v", c.expErr, err)
+			}
+
+			equals(t, c.expected, a)
+		})
+	}
+}
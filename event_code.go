@@ -0,0 +1,85 @@
+package adyen
+
+// EventCode is a type definition for notification event codes
+//
+// Link - https://docs.adyen.com/development-resources/webhooks/understand-notifications#event-codes
+type EventCode string
+
+// Standard notification event codes
+const (
+	EventCodeAuthorisation                  EventCode = "AUTHORISATION"
+	EventCodeAuthorisationAdjustment        EventCode = "AUTHORISATION_ADJUSTMENT"
+	EventCodeAutoRescue                     EventCode = "AUTORESCUE"
+	EventCodeCancelAutoRescue               EventCode = "CANCEL_AUTORESCUE"
+	EventCodeCancellation                   EventCode = "CANCELLATION"
+	EventCodeCancelOrRefund                 EventCode = "CANCEL_OR_REFUND"
+	EventCodeCapture                        EventCode = "CAPTURE"
+	EventCodeCaptureFailed                  EventCode = "CAPTURE_FAILED"
+	EventCodeChargeback                     EventCode = "CHARGEBACK"
+	EventCodeChargebackReversed             EventCode = "CHARGEBACK_REVERSED"
+	EventCodeDisputeDefensePeriodEnded      EventCode = "DISPUTE_DEFENSE_PERIOD_ENDED"
+	EventCodeExpire                         EventCode = "EXPIRE"
+	EventCodeHandledExternally              EventCode = "HANDLED_EXTERNALLY"
+	EventCodeInformationSupplied            EventCode = "INFORMATION_SUPPLIED"
+	EventCodeIssuerResponseTimeframeExpired EventCode = "ISSUER_RESPONSE_TIMEFRAME_EXPIRED"
+	EventCodeManualReviewAccept             EventCode = "MANUAL_REVIEW_ACCEPT"
+	EventCodeManualReviewReject             EventCode = "MANUAL_REVIEW_REJECT"
+	EventCodeNotificationOfChargeback       EventCode = "NOTIFICATION_OF_CHARGEBACK"
+	EventCodeNotificationOfFraud            EventCode = "NOTIFICATION_OF_FRAUD"
+	EventCodeOfferClosed                    EventCode = "OFFER_CLOSED"
+	EventCodeOrderClosed                    EventCode = "ORDER_CLOSED"
+	EventCodeOrderOpened                    EventCode = "ORDER_OPENED"
+	EventCodePaidoutReversed                EventCode = "PAIDOUT_REVERSED"
+	EventCodePayoutDecline                  EventCode = "PAYOUT_DECLINE"
+	EventCodePayoutExpire                   EventCode = "PAYOUT_EXPIRE"
+	EventCodePayoutThirdparty               EventCode = "PAYOUT_THIRDPARTY"
+	EventCodePending                        EventCode = "PENDING"
+	EventCodePostponedRefund                EventCode = "POSTPONED_REFUND"
+	EventCodePrearbitrationLost             EventCode = "PREARBITRATION_LOST"
+	EventCodePrearbitrationWon              EventCode = "PREARBITRATION_WON"
+	EventCodeRecurringContract              EventCode = "RECURRING_CONTRACT"
+	EventCodeRefund                         EventCode = "REFUND"
+	EventCodeRefundFailed                   EventCode = "REFUND_FAILED"
+	EventCodeRefundedReversed               EventCode = "REFUNDED_REVERSED"
+	EventCodeRefundWithData                 EventCode = "REFUND_WITH_DATA"
+	EventCodeReportAvailable                EventCode = "REPORT_AVAILABLE"
+	EventCodeRequestForInformation          EventCode = "REQUEST_FOR_INFORMATION"
+	EventCodeSecondChargeback               EventCode = "SECOND_CHARGEBACK"
+	EventCodeTechnicalCancel                EventCode = "TECHNICAL_CANCEL"
+	EventCodeVoidPendingRefund              EventCode = "VOID_PENDING_REFUND"
+)
+
+// IsChargeback - checks if event is a part of a dispute process
+func (e EventCode) IsChargeback() bool {
+	switch e {
+	case EventCodeChargeback,
+		EventCodeChargebackReversed,
+		EventCodeNotificationOfChargeback,
+		EventCodePrearbitrationLost,
+		EventCodePrearbitrationWon,
+		EventCodeRequestForInformation,
+		EventCodeSecondChargeback:
+		return true
+	}
+
+	return false
+}
+
+// IsModification - checks if event is a result of a modification of the original payment
+func (e EventCode) IsModification() bool {
+	switch e {
+	case EventCodeAuthorisationAdjustment,
+		EventCodeCancellation,
+		EventCodeCancelOrRefund,
+		EventCodeCapture,
+		EventCodeCaptureFailed,
+		EventCodeRefund,
+		EventCodeRefundFailed,
+		EventCodeRefundedReversed,
+		EventCodeTechnicalCancel,
+		EventCodeVoidPendingRefund:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,325 @@
+package adyen
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+/**************
+* HPP request *
+**************/
+
+// HPPPage is a type definition for Adyen Hosted Payment Pages endpoints
+type HPPPage string
+
+// Hosted Payment Pages endpoints
+//
+// Link - https://docs.adyen.com/developers/api-reference/hosted-payment-pages-api
+const (
+	HPPPageSelect      HPPPage = "select"      // multiple-page payment, shopper selects payment method
+	HPPPagePay         HPPPage = "pay"         // one-page payment, all payment methods on a single page
+	HPPPageDetails     HPPPage = "details"     // payment details page of a method given in BrandCode
+	HPPPageSkipDetails HPPPage = "skipDetails" // direct redirect to a method given in BrandCode
+)
+
+// HPP billing and delivery address types
+const (
+	HPPAddressTypeEditable = ""  // address is shown and could be changed by shopper
+	HPPAddressTypeVisible  = "1" // address is shown, but couldn't be changed
+	HPPAddressTypeHidden   = "2" // address is not shown
+)
+
+// HPPRequest contains data to start payment on Adyen Hosted Payment Pages
+//
+// All fields, that are sent, are signed with merchantSig by CalculateSignature, including
+// shopper, billing and delivery address fields. New fields could be added with url tag.
+//
+// Link - https://docs.adyen.com/developers/api-reference/hosted-payment-pages-api#paymentsetuprequest
+type HPPRequest struct {
+	MerchantReference string `url:"merchantReference"`
+	PaymentAmount     int64  `url:"paymentAmount"`
+	CurrencyCode      string `url:"currencyCode"`
+	ShipBeforeDate    string `url:"shipBeforeDate"`
+	SkinCode          string `url:"skinCode"`
+	MerchantAccount   string `url:"merchantAccount"`
+	SessionValidity   string `url:"sessionValidity"`
+	MerchantSig       string `url:"merchantSig"`
+
+	ShopperLocale      string `url:"shopperLocale,omitempty"`
+	CountryCode        string `url:"countryCode,omitempty"`
+	BrandCode          string `url:"brandCode,omitempty"`
+	IssuerID           string `url:"issuerId,omitempty"`
+	AllowedMethods     string `url:"allowedMethods,omitempty"` // comma separated list of brand codes
+	BlockedMethods     string `url:"blockedMethods,omitempty"` // comma separated list of brand codes
+	ResURL             string `url:"resURL,omitempty"`
+	MerchantReturnData string `url:"merchantReturnData,omitempty"`
+	OfferEmail         string `url:"offerEmail,omitempty"`
+
+	ShopperEmail           string       `url:"shopperEmail,omitempty"`
+	ShopperReference       string       `url:"shopperReference,omitempty"`
+	RecurringContract      ContractType `url:"recurringContract,omitempty"`
+	ShopperFirstName       string       `url:"shopper.firstName,omitempty"`
+	ShopperLastName        string       `url:"shopper.lastName,omitempty"`
+	ShopperTelephoneNumber string       `url:"shopper.telephoneNumber,omitempty"`
+
+	BillingAddressType              string `url:"billingAddressType,omitempty"`
+	BillingAddressStreet            string `url:"billingAddress.street,omitempty"`
+	BillingAddressHouseNumberOrName string `url:"billingAddress.houseNumberOrName,omitempty"`
+	BillingAddressCity              string `url:"billingAddress.city,omitempty"`
+	BillingAddressPostalCode        string `url:"billingAddress.postalCode,omitempty"`
+	BillingAddressStateOrProvince   string `url:"billingAddress.stateOrProvince,omitempty"`
+	BillingAddressCountry           string `url:"billingAddress.country,omitempty"`
+
+	DeliveryAddressType              string `url:"deliveryAddressType,omitempty"`
+	DeliveryAddressStreet            string `url:"deliveryAddress.street,omitempty"`
+	DeliveryAddressHouseNumberOrName string `url:"deliveryAddress.houseNumberOrName,omitempty"`
+	DeliveryAddressCity              string `url:"deliveryAddress.city,omitempty"`
+	DeliveryAddressPostalCode        string `url:"deliveryAddress.postalCode,omitempty"`
+	DeliveryAddressStateOrProvince   string `url:"deliveryAddress.stateOrProvince,omitempty"`
+	DeliveryAddressCountry           string `url:"deliveryAddress.country,omitempty"`
+
+	OpenInvoiceData *OpenInvoiceData `url:"openinvoicedata,omitempty"`
+}
+
+// SetBillingAddress - fills billing address fields, addressType is one of HPPAddressType constants
+func (r *HPPRequest) SetBillingAddress(a *Address, addressType string) {
+	r.BillingAddressType = addressType
+	r.BillingAddressStreet = a.Street
+	r.BillingAddressHouseNumberOrName = a.HouseNumberOrName
+	r.BillingAddressCity = a.City
+	r.BillingAddressPostalCode = a.PostalCode
+	r.BillingAddressStateOrProvince = a.StateOrProvince
+	r.BillingAddressCountry = a.Country
+}
+
+// SetDeliveryAddress - fills delivery address fields, addressType is one of HPPAddressType constants
+func (r *HPPRequest) SetDeliveryAddress(a *Address, addressType string) {
+	r.DeliveryAddressType = addressType
+	r.DeliveryAddressStreet = a.Street
+	r.DeliveryAddressHouseNumberOrName = a.HouseNumberOrName
+	r.DeliveryAddressCity = a.City
+	r.DeliveryAddressPostalCode = a.PostalCode
+	r.DeliveryAddressStateOrProvince = a.StateOrProvince
+	r.DeliveryAddressCountry = a.Country
+}
+
+// CalculateSignature calculate HMAC signature for request
+//
+// All fields with url tag, that are going to be sent, are signed, see SignHPPFields.
+// Open invoice fields are signed separately into openinvoicedata.sig as well
+func (r *HPPRequest) CalculateSignature(adyen *Adyen) error {
+	if r.MerchantAccount == "" || r.SkinCode == "" || adyen.Credentials.Hmac == "" {
+		return errors.New("merchantID, skinCode and HMAC hash need to be specified")
+	}
+
+	if r.OpenInvoiceData != nil {
+		if err := r.OpenInvoiceData.sign(adyen.Credentials.Hmac); err != nil {
+			return err
+		}
+	}
+
+	sig, err := signHPPRequest(r, adyen.Credentials.Hmac)
+	if err != nil {
+		return err
+	}
+
+	r.MerchantSig = sig
+	return nil
+}
+
+// hppFormTemplate - HTML page, that submits HPP request with POST as soon as it's loaded
+var hppFormTemplate = template.Must(template.New("hpp").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Redirecting to payment page</title></head>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.Action}}">
+{{- range .Fields}}
+<input type="hidden" name="{{.Name}}" value="{{.Value}}">
+{{- end}}
+<noscript><button type="submit">Continue to payment</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// hppFormField - hidden input of HPP form
+type hppFormField struct {
+	Name  string
+	Value string
+}
+
+// HPPURL - signs request and generates link to a given Hosted Payment Pages endpoint
+//
+// HPPPageDetails and HPPPageSkipDetails require BrandCode to be specified
+func (a *PaymentGateway) HPPURL(page HPPPage, req *HPPRequest) (string, error) {
+	v, err := a.signedHPPValues(page, req)
+	if err != nil {
+		return "", err
+	}
+
+	return a.createHPPUrl(string(page)) + "?" + v.Encode(), nil
+}
+
+// HPPForm - signs request and generates HTML page, that POSTs it to a given Hosted Payment Pages endpoint
+//
+// Use it instead of HPPURL if request is too long for a redirect URL, f.e.:
+//
+//	page, err := instance.Payment().HPPForm(adyen.HPPPagePay, req)
+//	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+//	w.Write(page)
+func (a *PaymentGateway) HPPForm(page HPPPage, req *HPPRequest) ([]byte, error) {
+	v, err := a.signedHPPValues(page, req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]hppFormField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, hppFormField{Name: name, Value: v.Get(name)})
+	}
+
+	buf := new(bytes.Buffer)
+	err = hppFormTemplate.Execute(buf, struct {
+		Action string
+		Fields []hppFormField
+	}{
+		Action: a.createHPPUrl(string(page)),
+		Fields: fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signedHPPValues - validates and signs HPP request, returns fields to be sent
+func (a *PaymentGateway) signedHPPValues(page HPPPage, req *HPPRequest) (url.Values, error) {
+	switch page {
+	case HPPPageSelect, HPPPagePay:
+	case HPPPageDetails, HPPPageSkipDetails:
+		if req.BrandCode == "" {
+			return nil, fmt.Errorf("brandCode need to be specified for %s page", page)
+		}
+	default:
+		return nil, fmt.Errorf("unknown HPP page %q", page)
+	}
+
+	if err := req.CalculateSignature(a.Adyen); err != nil {
+		return nil, err
+	}
+
+	return query.Values(req)
+}
+
+/*************
+* HPP result *
+*************/
+
+// AuthResult is a type definition for a payment result, returned by Adyen HPP to resURL
+type AuthResult string
+
+// HPP payment results
+//
+// Link - https://docs.adyen.com/developers/api-reference/hosted-payment-pages-api#paymentresult
+const (
+	AuthResultAuthorised AuthResult = "AUTHORISED"
+	AuthResultRefused    AuthResult = "REFUSED"
+	AuthResultCancelled  AuthResult = "CANCELLED"
+	AuthResultPending    AuthResult = "PENDING"
+	AuthResultError      AuthResult = "ERROR"
+)
+
+// HPPResult contains parameters Adyen HPP passes to resURL, when shopper is redirected back
+//
+// PspReference is empty if payment was cancelled or failed with an error
+type HPPResult struct {
+	AuthResult         AuthResult
+	PspReference       string
+	MerchantReference  string
+	SkinCode           string
+	PaymentMethod      string
+	ShopperLocale      string
+	MerchantReturnData string
+	MerchantSig        string
+}
+
+// ParseHPPResult - parses and validates query parameters of redirect from Adyen HPP to resURL
+//
+// merchantSig is validated against all returned parameters with skin HMAC key,
+// verification keys are accepted as well, see WithHMACVerificationKey
+//
+// Example:
+//
+//	res, err := instance.Payment().ParseHPPResult(r.URL.Query())
+//	if err == nil && res.AuthResult == adyen.AuthResultAuthorised {
+//		...
+//	}
+func (a *PaymentGateway) ParseHPPResult(values url.Values) (*HPPResult, error) {
+	fields := make(map[string]string, len(values))
+	for name := range values {
+		fields[name] = values.Get(name)
+	}
+
+	if err := a.verifyHPPFields(fields); err != nil {
+		return nil, err
+	}
+
+	res := &HPPResult{
+		AuthResult:         AuthResult(fields["authResult"]),
+		PspReference:       fields["pspReference"],
+		MerchantReference:  fields["merchantReference"],
+		SkinCode:           fields["skinCode"],
+		PaymentMethod:      fields["paymentMethod"],
+		ShopperLocale:      fields["shopperLocale"],
+		MerchantReturnData: fields["merchantReturnData"],
+		MerchantSig:        fields[hppSignatureField],
+	}
+
+	switch res.AuthResult {
+	case AuthResultAuthorised, AuthResultRefused, AuthResultCancelled, AuthResultPending, AuthResultError:
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("unknown HPP authResult %q", res.AuthResult)
+}
+
+// verifyHPPFields - validates merchantSig of HPP fields with every HMAC key valid at the moment
+func (a *Adyen) verifyHPPFields(fields map[string]string) error {
+	provided := fields[hppSignatureField]
+	if provided == "" {
+		return errNoHMACSignature
+	}
+
+	keys := a.Credentials.hmacKeys(time.Now())
+	if len(keys) == 0 {
+		return errNoHMACKey
+	}
+
+	for _, key := range keys {
+		expected, err := SignHPPFields(fields, key)
+		if err != nil {
+			return err
+		}
+
+		if hmac.Equal([]byte(expected), []byte(provided)) {
+			return nil
+		}
+	}
+
+	return errInvalidSignature
+}
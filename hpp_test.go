@@ -0,0 +1,155 @@
+package adyen
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testHPPResultValues - HPP result parameters, signed with a given key
+func testHPPResultValues(t *testing.T, hmacKey string, authResult AuthResult) url.Values {
+	fields := map[string]string{
+		"authResult":        string(authResult),
+		"pspReference":      "8813824003752247",
+		"merchantReference": "order-1",
+		"skinCode":          "skin",
+		"paymentMethod":     "ideal",
+		"shopperLocale":     "nl_NL",
+	}
+
+	sig, err := SignHPPFields(fields, hmacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := url.Values{}
+	for name, value := range fields {
+		v.Set(name, value)
+	}
+	v.Set("merchantSig", sig)
+
+	return v
+}
+
+func TestParseHPPResult(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationHMAC)
+
+	res, err := instance.Payment().ParseHPPResult(testHPPResultValues(t, testNotificationHMAC, AuthResultAuthorised))
+	equals(t, nil, err)
+	equals(t, AuthResultAuthorised, res.AuthResult)
+	equals(t, "8813824003752247", res.PspReference)
+	equals(t, "order-1", res.MerchantReference)
+	equals(t, "ideal", res.PaymentMethod)
+
+	tampered := testHPPResultValues(t, testNotificationHMAC, AuthResultRefused)
+	tampered.Set("authResult", string(AuthResultAuthorised))
+	_, err = instance.Payment().ParseHPPResult(tampered)
+	equals(t, errInvalidSignature, err)
+
+	unsigned := testHPPResultValues(t, testNotificationHMAC, AuthResultAuthorised)
+	unsigned.Del("merchantSig")
+	_, err = instance.Payment().ParseHPPResult(unsigned)
+	equals(t, errNoHMACSignature, err)
+
+	_, err = instance.Payment().ParseHPPResult(testHPPResultValues(t, testNotificationHMAC, AuthResult("UNKNOWN")))
+	assert(t, err != nil, "expected unknown authResult to be rejected")
+}
+
+func TestParseHPPResultKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationNewHMAC,
+		WithHMACVerificationKey(testNotificationHMAC, time.Time{}, time.Now().Add(time.Hour)))
+
+	res, err := instance.Payment().ParseHPPResult(testHPPResultValues(t, testNotificationHMAC, AuthResultPending))
+	equals(t, nil, err)
+	equals(t, AuthResultPending, res.AuthResult)
+
+	_, err = NewWithHMAC(Testing, "username", "password", testNotificationNewHMAC).
+		Payment().ParseHPPResult(testHPPResultValues(t, testNotificationHMAC, AuthResultPending))
+	equals(t, errInvalidSignature, err)
+}
+
+func testHPPRequest() *HPPRequest {
+	req := &HPPRequest{
+		MerchantReference: "order-1",
+		PaymentAmount:     1000,
+		CurrencyCode:      "EUR",
+		ShipBeforeDate:    "2015-11-31T13:42:40+1:00",
+		SkinCode:          "skin",
+		MerchantAccount:   "TestMerchant",
+		SessionValidity:   "2015-11-29T13:42:40+1:00",
+		ShopperEmail:      "shopper@example.com",
+		ShopperReference:  "shopper-1",
+		RecurringContract: ContractOneClick,
+		AllowedMethods:    "visa,mc",
+		ResURL:            "https://example.com/result",
+	}
+	req.SetBillingAddress(&Address{
+		Street:            "Simon Carmiggeltstraat",
+		HouseNumberOrName: "6-50",
+		City:              "Amsterdam",
+		PostalCode:        "1011 DJ",
+		Country:           "NL",
+	}, HPPAddressTypeVisible)
+
+	return req
+}
+
+func TestHPPURL(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationHMAC)
+
+	for _, page := range []HPPPage{HPPPageSelect, HPPPagePay} {
+		link, err := instance.Payment().HPPURL(page, testHPPRequest())
+		equals(t, nil, err)
+
+		u, err := url.Parse(link)
+		equals(t, nil, err)
+		equals(t, "/hpp/"+string(page)+".shtml", u.Path)
+
+		q := u.Query()
+		equals(t, "Amsterdam", q.Get("billingAddress.city"))
+		equals(t, HPPAddressTypeVisible, q.Get("billingAddressType"))
+		equals(t, RecurringPaymentOneClick, q.Get("recurringContract"))
+		_, sent := q["deliveryAddress.city"]
+		assert(t, !sent, "expected empty optional fields not to be sent")
+
+		fields := make(map[string]string, len(q))
+		for name := range q {
+			fields[name] = q.Get(name)
+		}
+		equals(t, nil, instance.verifyHPPFields(fields))
+	}
+
+	_, err := instance.Payment().HPPURL(HPPPageDetails, testHPPRequest())
+	assert(t, err != nil, "expected details page to require brandCode")
+
+	req := testHPPRequest()
+	req.BrandCode = "ideal"
+	_, err = instance.Payment().HPPURL(HPPPageDetails, req)
+	equals(t, nil, err)
+}
+
+func TestHPPForm(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationHMAC)
+
+	req := testHPPRequest()
+	req.MerchantReturnData = `"><script>alert(1)</script>`
+
+	page, err := instance.Payment().HPPForm(HPPPagePay, req)
+	equals(t, nil, err)
+
+	html := string(page)
+	assert(t, strings.Contains(html, `action="https://test.adyen.com/hpp/pay.shtml"`), "expected form to post to pay.shtml")
+	assert(t, strings.Contains(html, `name="merchantSig" value="`+req.MerchantSig+`"`), "expected form to contain signature")
+	assert(t, strings.Contains(html, `name="billingAddress.postalCode" value="1011 DJ"`), "expected form to contain billing address")
+	assert(t, !strings.Contains(html, "<script>alert"), "expected field values to be escaped")
+	assert(t, strings.Contains(html, "document.forms[0].submit()"), "expected form to be submitted automatically")
+}
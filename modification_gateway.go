@@ -1,5 +1,7 @@
 package adyen
 
+import "context"
+
 // Adyen Modification actions
 const (
 	captureType         = "capture"
@@ -17,9 +19,14 @@ type ModificationGateway struct {
 
 // Capture - Perform capture payment in Adyen
 func (a *ModificationGateway) Capture(req *Capture) (*CaptureResponse, error) {
+	return a.CaptureContext(context.Background(), req)
+}
+
+// CaptureContext - Perform capture payment in Adyen, bound to a given context
+func (a *ModificationGateway) CaptureContext(ctx context.Context, req *Capture) (*CaptureResponse, error) {
 	url := a.adyenURL(PaymentService, captureType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -30,9 +37,14 @@ func (a *ModificationGateway) Capture(req *Capture) (*CaptureResponse, error) {
 
 // Cancel - Perform cancellation of the authorised transaction
 func (a *ModificationGateway) Cancel(req *Cancel) (*CancelResponse, error) {
+	return a.CancelContext(context.Background(), req)
+}
+
+// CancelContext - Perform cancellation of the authorised transaction, bound to a given context
+func (a *ModificationGateway) CancelContext(ctx context.Context, req *Cancel) (*CancelResponse, error) {
 	url := a.adyenURL(PaymentService, cancelType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -44,9 +56,14 @@ func (a *ModificationGateway) Cancel(req *Cancel) (*CancelResponse, error) {
 // CancelOrRefund - Perform cancellation for not captured transaction
 // otherwise perform refund action
 func (a *ModificationGateway) CancelOrRefund(req *Cancel) (*CancelOrRefundResponse, error) {
+	return a.CancelOrRefundContext(context.Background(), req)
+}
+
+// CancelOrRefundContext - Perform cancellation or refund of the transaction, bound to a given context
+func (a *ModificationGateway) CancelOrRefundContext(ctx context.Context, req *Cancel) (*CancelOrRefundResponse, error) {
 	url := a.adyenURL(PaymentService, cancelOrRefundType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -57,9 +74,14 @@ func (a *ModificationGateway) CancelOrRefund(req *Cancel) (*CancelOrRefundRespon
 
 // Refund - perform refund for already captured request
 func (a *ModificationGateway) Refund(req *Refund) (*RefundResponse, error) {
+	return a.RefundContext(context.Background(), req)
+}
+
+// RefundContext - perform refund for already captured request, bound to a given context
+func (a *ModificationGateway) RefundContext(ctx context.Context, req *Refund) (*RefundResponse, error) {
 	url := a.adyenURL(PaymentService, refundType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -72,9 +94,14 @@ func (a *ModificationGateway) Refund(req *Refund) (*RefundResponse, error) {
 //
 // Link - https://docs.adyen.com/developers/payment-modifications#adjustauthorisation
 func (a *ModificationGateway) AdjustAuthorisation(req *AdjustAuthorisation) (*AdjustAuthorisationResponse, error) {
+	return a.AdjustAuthorisationContext(context.Background(), req)
+}
+
+// AdjustAuthorisationContext - perform adjustAuthorisation request, bound to a given context
+func (a *ModificationGateway) AdjustAuthorisationContext(ctx context.Context, req *AdjustAuthorisation) (*AdjustAuthorisationResponse, error) {
 	url := a.adyenURL(PaymentService, adjustAuthorisation, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -87,9 +114,14 @@ func (a *ModificationGateway) AdjustAuthorisation(req *AdjustAuthorisation) (*Ad
 //
 // Link - https://docs.adyen.com/developers/payment-modifications#technicalcancel
 func (a *ModificationGateway) TechnicalCancel(req *TechnicalCancel) (*TechnicalCancelResponse, error) {
+	return a.TechnicalCancelContext(context.Background(), req)
+}
+
+// TechnicalCancelContext - perform technical cancellation, bound to a given context
+func (a *ModificationGateway) TechnicalCancelContext(ctx context.Context, req *TechnicalCancel) (*TechnicalCancelResponse, error) {
 	url := a.adyenURL(PaymentService, technicalCancel, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -21,32 +21,214 @@ type NotificationRequestItem struct {
 
 // NotificationRequestItemData contains the NotificationRequestItem data.
 type NotificationRequestItemData struct {
-	AdditionalData struct {
-		ShopperReference         string `json:"shopperReference,omitempty"`
-		ShopperEmail             string `json:"shopperEmail,omitempty"`
-		AuthCode                 string `json:"authCode,omitempty"`
-		CardSummary              string `json:"cardSummary,omitempty"`
-		ExpiryDate               string `json:"expiryDate,omitempty"`
-		AuthorisedAmountValue    string `json:"authorisedAmountValue,omitempty"`
-		AuthorisedAmountCurrency string `json:"authorisedAmountCurrency,omitempty"`
-		HmacSignature            string `json:"hmacSignature,omitempty"`
-		NOFReasonCode            string `json:"nofReasonCode,omitempty"`
-		NOFSchemeCode            string `json:"nofSchemeCode,omitempty"`
-		RFIReasonCode            string `json:"rfiReasonCode,omitempty"`
-		RFISchemeCode            string `json:"rfiSchemeCode,omitempty"`
-		ChargebackReasonCode     string `json:"chargebackReasonCode,omitempty"`
-		ChargebackSchemeCode     string `json:"chargebackSchemeCode,omitempty"`
-		ARN                      string `json:"arn,omitempty"`
-	} `json:"additionalData,omitempty"`
-	Amount              Amount     `json:"amount"`
-	PspReference        string     `json:"pspReference"`
-	EventCode           string     `json:"eventCode"`
-	EventDate           time.Time  `json:"eventDate"` // Event date in time.RFC3339 format
-	MerchantAccountCode string     `json:"merchantAccountCode"`
-	Operations          []string   `json:"operations"`
-	MerchantReference   string     `json:"merchantReference"`
-	OriginalReference   string     `json:"originalReference,omitempty"`
-	PaymentMethod       string     `json:"paymentMethod"`
-	Reason              string     `json:"reason,omitempty"`
-	Success             StringBool `json:"success"`
+	AdditionalData      NotificationAdditionalData `json:"additionalData,omitempty"`
+	Amount              Amount                     `json:"amount"`
+	PspReference        string                     `json:"pspReference"`
+	EventCode           EventCode                  `json:"eventCode"`
+	EventDate           time.Time                  `json:"eventDate"` // Event date in time.RFC3339 format
+	MerchantAccountCode string                     `json:"merchantAccountCode"`
+	Operations          []string                   `json:"operations"`
+	MerchantReference   string                     `json:"merchantReference"`
+	OriginalReference   string                     `json:"originalReference,omitempty"`
+	PaymentMethod       string                     `json:"paymentMethod"`
+	Reason              string                     `json:"reason,omitempty"`
+	Success             StringBool                 `json:"success"`
+}
+
+// NotificationAdditionalData contains additional data of notification item
+//
+// Keys, that are not modelled as struct fields, are kept in Extra
+type NotificationAdditionalData struct {
+	ShopperReference         string    `json:"shopperReference,omitempty"`
+	ShopperEmail             string    `json:"shopperEmail,omitempty"`
+	AuthCode                 string    `json:"authCode,omitempty"`
+	CardSummary              string    `json:"cardSummary,omitempty"`
+	ExpiryDate               string    `json:"expiryDate,omitempty"`
+	AuthorisedAmountValue    string    `json:"authorisedAmountValue,omitempty"`
+	AuthorisedAmountCurrency string    `json:"authorisedAmountCurrency,omitempty"`
+	HmacSignature            string    `json:"hmacSignature,omitempty"`
+	NOFReasonCode            string    `json:"nofReasonCode,omitempty"`
+	NOFSchemeCode            string    `json:"nofSchemeCode,omitempty"`
+	RFIReasonCode            string    `json:"rfiReasonCode,omitempty"`
+	RFISchemeCode            string    `json:"rfiSchemeCode,omitempty"`
+	ChargebackReasonCode     string    `json:"chargebackReasonCode,omitempty"`
+	ChargebackSchemeCode     string    `json:"chargebackSchemeCode,omitempty"`
+	ARN                      string    `json:"arn,omitempty"`
+	Extra                    ExtraData `json:"-"`
+}
+
+// AuthorisationNotification - view of AUTHORISATION notification
+//
+// If Success is false, Reason contains a refusal reason
+type AuthorisationNotification struct {
+	PspReference      string
+	MerchantReference string
+	Amount            Amount
+	Success           bool
+	Reason            string
+	PaymentMethod     string
+	Operations        []string
+	AuthCode          string
+	CardSummary       string
+	ExpiryDate        string
+	ShopperReference  string
+	EventDate         time.Time
+}
+
+// ModificationNotification - view of a modification notification, f.e. CAPTURE, REFUND or CANCELLATION
+//
+// PspReference is the reference of the modification, OriginalReference - of the modified payment
+type ModificationNotification struct {
+	EventCode         EventCode
+	PspReference      string
+	OriginalReference string
+	MerchantReference string
+	Amount            Amount
+	Success           bool
+	Reason            string
+	EventDate         time.Time
+}
+
+// ChargebackNotification - view of a dispute notification, f.e. CHARGEBACK, NOTIFICATION_OF_CHARGEBACK
+// or REQUEST_FOR_INFORMATION
+//
+// PspReference is the reference of the dispute, OriginalReference - of the disputed payment.
+// ReasonCode and SchemeCode are taken from the fields relevant for the event
+type ChargebackNotification struct {
+	EventCode         EventCode
+	PspReference      string
+	OriginalReference string
+	MerchantReference string
+	Amount            Amount
+	Reason            string
+	ReasonCode        string
+	SchemeCode        string
+	ARN               string
+	EventDate         time.Time
+}
+
+// ReportAvailableNotification - view of REPORT_AVAILABLE notification
+//
+// Report could be downloaded from ReportURL with report user credentials
+type ReportAvailableNotification struct {
+	MerchantAccountCode string
+	ReportName          string
+	ReportURL           string
+	EventDate           time.Time
+}
+
+// RecurringContractNotification - view of RECURRING_CONTRACT notification
+//
+// OriginalReference is the reference of the payment, that created recurring contract
+type RecurringContractNotification struct {
+	RecurringDetailReference string
+	OriginalReference        string
+	MerchantReference        string
+	ShopperReference         string
+	PaymentMethod            string
+	Success                  bool
+	EventDate                time.Time
+}
+
+// AsAuthorisation - returns AUTHORISATION view of the notification, false if event is different
+func (n NotificationRequestItemData) AsAuthorisation() (*AuthorisationNotification, bool) {
+	if n.EventCode != EventCodeAuthorisation {
+		return nil, false
+	}
+
+	return &AuthorisationNotification{
+		PspReference:      n.PspReference,
+		MerchantReference: n.MerchantReference,
+		Amount:            n.Amount,
+		Success:           bool(n.Success),
+		Reason:            n.Reason,
+		PaymentMethod:     n.PaymentMethod,
+		Operations:        n.Operations,
+		AuthCode:          n.AdditionalData.AuthCode,
+		CardSummary:       n.AdditionalData.CardSummary,
+		ExpiryDate:        n.AdditionalData.ExpiryDate,
+		ShopperReference:  n.AdditionalData.ShopperReference,
+		EventDate:         n.EventDate,
+	}, true
+}
+
+// AsModification - returns modification view of the notification, false if event is not a modification
+func (n NotificationRequestItemData) AsModification() (*ModificationNotification, bool) {
+	if !n.EventCode.IsModification() {
+		return nil, false
+	}
+
+	return &ModificationNotification{
+		EventCode:         n.EventCode,
+		PspReference:      n.PspReference,
+		OriginalReference: n.OriginalReference,
+		MerchantReference: n.MerchantReference,
+		Amount:            n.Amount,
+		Success:           bool(n.Success),
+		Reason:            n.Reason,
+		EventDate:         n.EventDate,
+	}, true
+}
+
+// AsChargeback - returns dispute view of the notification, false if event is not a part of dispute process
+func (n NotificationRequestItemData) AsChargeback() (*ChargebackNotification, bool) {
+	if !n.EventCode.IsChargeback() {
+		return nil, false
+	}
+
+	reasonCode, schemeCode := n.AdditionalData.ChargebackReasonCode, n.AdditionalData.ChargebackSchemeCode
+	switch n.EventCode {
+	case EventCodeNotificationOfChargeback:
+		reasonCode, schemeCode = n.AdditionalData.NOFReasonCode, n.AdditionalData.NOFSchemeCode
+	case EventCodeRequestForInformation:
+		reasonCode, schemeCode = n.AdditionalData.RFIReasonCode, n.AdditionalData.RFISchemeCode
+	}
+
+	return &ChargebackNotification{
+		EventCode:         n.EventCode,
+		PspReference:      n.PspReference,
+		OriginalReference: n.OriginalReference,
+		MerchantReference: n.MerchantReference,
+		Amount:            n.Amount,
+		Reason:            n.Reason,
+		ReasonCode:        reasonCode,
+		SchemeCode:        schemeCode,
+		ARN:               n.AdditionalData.ARN,
+		EventDate:         n.EventDate,
+	}, true
+}
+
+// AsReportAvailable - returns REPORT_AVAILABLE view of the notification, false if event is different
+//
+// Adyen sends report file name as pspReference and download URL as reason
+func (n NotificationRequestItemData) AsReportAvailable() (*ReportAvailableNotification, bool) {
+	if n.EventCode != EventCodeReportAvailable {
+		return nil, false
+	}
+
+	return &ReportAvailableNotification{
+		MerchantAccountCode: n.MerchantAccountCode,
+		ReportName:          n.PspReference,
+		ReportURL:           n.Reason,
+		EventDate:           n.EventDate,
+	}, true
+}
+
+// AsRecurringContract - returns RECURRING_CONTRACT view of the notification, false if event is different
+//
+// Adyen sends recurring detail reference as pspReference
+func (n NotificationRequestItemData) AsRecurringContract() (*RecurringContractNotification, bool) {
+	if n.EventCode != EventCodeRecurringContract {
+		return nil, false
+	}
+
+	return &RecurringContractNotification{
+		RecurringDetailReference: n.PspReference,
+		OriginalReference:        n.OriginalReference,
+		MerchantReference:        n.MerchantReference,
+		ShopperReference:         n.AdditionalData.ShopperReference,
+		PaymentMethod:            n.PaymentMethod,
+		Success:                  bool(n.Success),
+		EventDate:                n.EventDate,
+	}, true
 }
@@ -0,0 +1,200 @@
+package adyen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDispatcherWorkers - default number of workers processing notifications
+	DefaultDispatcherWorkers = 4
+
+	// DefaultDispatcherMaxAttempts - default number of processing attempts before item is dead-lettered
+	DefaultDispatcherMaxAttempts = 10
+
+	// DefaultDispatcherInitialBackoff - default delay before the first processing retry
+	DefaultDispatcherInitialBackoff = time.Second * 10
+
+	// DefaultDispatcherMaxBackoff - default upper bound of a delay between processing retries
+	DefaultDispatcherMaxBackoff = time.Hour
+)
+
+// DeadLetterFunc - function called for notification item, that failed all processing attempts
+//
+// Item is removed from the queue after the call, so it should be stored for manual investigation
+type DeadLetterFunc func(ctx context.Context, n *QueuedNotification, err error)
+
+// NotificationDispatcher - processes notification items from NotificationQueue on a worker pool
+//
+// Failed items are returned to the queue with exponential backoff according to the retry policy.
+// Items, that failed all attempts, are passed to the dead-letter hook and removed from the queue.
+// Without dead-letter hook items are never dropped and retried with backoff up to the maximum one.
+//
+// Example:
+//
+//	queue := adyen.NewMemoryNotificationQueue()
+//	h := adyen.NewNotificationHandler(instance, adyen.WithNotificationQueue(queue))
+//	h.Handle(adyen.EventCodeAuthorisation, markPaid)
+//
+//	d := adyen.NewNotificationDispatcher(queue, h.Process, adyen.WithDeadLetter(alert))
+//	go d.Run(ctx)
+type NotificationDispatcher struct {
+	queue        NotificationQueue
+	process      NotificationCallback
+	workers      int
+	retryPolicy  RetryPolicy
+	deadLetter   DeadLetterFunc
+	errorHandler func(err error)
+}
+
+// NotificationDispatcherOption allows for custom configuration of NotificationDispatcher.
+type NotificationDispatcherOption func(*NotificationDispatcher)
+
+// WithDispatcherWorkers sets number of concurrent workers, DefaultDispatcherWorkers is used by default.
+func WithDispatcherWorkers(n int) NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// WithDispatcherRetryPolicy sets number of processing attempts and delays between them.
+//
+// Zero values of the policy are replaced with DefaultDispatcherMaxAttempts, DefaultDispatcherInitialBackoff
+// and DefaultDispatcherMaxBackoff
+func WithDispatcherRetryPolicy(p RetryPolicy) NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		if p.MaxAttempts > 0 {
+			d.retryPolicy.MaxAttempts = p.MaxAttempts
+		}
+		if p.InitialBackoff > 0 {
+			d.retryPolicy.InitialBackoff = p.InitialBackoff
+		}
+		if p.MaxBackoff > 0 {
+			d.retryPolicy.MaxBackoff = p.MaxBackoff
+		}
+	}
+}
+
+// WithDeadLetter sets hook for items, that failed all processing attempts.
+//
+// Without the hook such items stay in the queue and are retried with backoff up to the maximum one
+func WithDeadLetter(fn DeadLetterFunc) NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		d.deadLetter = fn
+	}
+}
+
+// WithDispatcherErrorHandler sets function to report queue errors, that can't be returned to a caller.
+func WithDispatcherErrorHandler(fn func(err error)) NotificationDispatcherOption {
+	return func(d *NotificationDispatcher) {
+		d.errorHandler = fn
+	}
+}
+
+// NewNotificationDispatcher - creates NotificationDispatcher instance
+//
+// process is called for every queued item, usually it's NotificationHandler.Process
+func NewNotificationDispatcher(q NotificationQueue, process NotificationCallback, opts ...NotificationDispatcherOption) *NotificationDispatcher {
+	d := &NotificationDispatcher{
+		queue:   q,
+		process: process,
+		workers: DefaultDispatcherWorkers,
+		retryPolicy: RetryPolicy{
+			MaxAttempts:    DefaultDispatcherMaxAttempts,
+			InitialBackoff: DefaultDispatcherInitialBackoff,
+			MaxBackoff:     DefaultDispatcherMaxBackoff,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Run - processes queued items until ctx is done
+//
+// Run blocks until all workers are stopped and returns ctx error
+func (d *NotificationDispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	wg.Add(d.workers)
+
+	for i := 0; i < d.workers; i++ {
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// work - pops and processes items one by one until ctx is done
+func (d *NotificationDispatcher) work(ctx context.Context) {
+	for attempt := 1; ctx.Err() == nil; {
+		n, err := d.queue.Pop(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			d.reportError(err)
+			if werr := d.retryPolicy.wait(ctx, attempt); werr != nil {
+				return
+			}
+			attempt++
+			continue
+		}
+
+		attempt = 1
+		d.handle(ctx, n)
+	}
+}
+
+// handle - processes a single item and acknowledges, retries or dead-letters it
+func (d *NotificationDispatcher) handle(ctx context.Context, n *QueuedNotification) {
+	err := d.process(ctx, n.Item)
+	if err == nil {
+		d.reportError(d.queue.Ack(ctx, n))
+		return
+	}
+
+	if ctx.Err() != nil {
+		// dispatcher is stopped, attempt is not counted and item is returned to the queue right away;
+		// ctx is already done, so the queue is called without it
+		d.reportError(d.queue.Retry(context.Background(), n, time.Now()))
+		return
+	}
+
+	n.Attempts++
+	n.LastError = err.Error()
+
+	if n.Attempts < d.retryPolicy.maxAttempts() {
+		at := time.Now().Add(d.retryPolicy.backoff(n.Attempts))
+		d.reportError(d.queue.Retry(ctx, n, at))
+		return
+	}
+
+	if d.deadLetter == nil {
+		d.reportError(fmt.Errorf("notification %s failed %d attempts: %v", n.Item.PspReference, n.Attempts, err))
+		d.reportError(d.queue.Retry(ctx, n, time.Now().Add(d.retryPolicy.backoff(n.Attempts))))
+		return
+	}
+
+	d.deadLetter(ctx, n, err)
+	d.reportError(d.queue.Ack(ctx, n))
+}
+
+// reportError - passes error to the error handler, if configured
+func (d *NotificationDispatcher) reportError(err error) {
+	if err != nil && d.errorHandler != nil {
+		d.errorHandler(err)
+	}
+}
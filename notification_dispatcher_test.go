@@ -0,0 +1,208 @@
+package adyen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotificationDispatcherRetries(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	q := NewMemoryNotificationQueue()
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "flaky"}))
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "broken"}))
+
+	var (
+		mu       sync.Mutex
+		attempts = map[string]int{}
+		dead     []*QueuedNotification
+	)
+	done := make(chan struct{}, 2)
+
+	process := func(ctx context.Context, item NotificationRequestItemData) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[item.PspReference]++
+		if item.PspReference == "broken" || attempts[item.PspReference] < 2 {
+			return errors.New("ledger is not available")
+		}
+
+		done <- struct{}{}
+		return nil
+	}
+
+	d := NewNotificationDispatcher(q, process,
+		WithDispatcherWorkers(2),
+		WithDispatcherRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond * 5}),
+		WithDeadLetter(func(ctx context.Context, n *QueuedNotification, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			dead = append(dead, n)
+			done <- struct{}{}
+		}),
+	)
+
+	stopped := make(chan error)
+	runCtx, stop := context.WithCancel(ctx)
+	go func() { stopped <- d.Run(runCtx) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			t.Fatal("notifications are not processed in time")
+		}
+	}
+
+	stop()
+	equals(t, context.Canceled, <-stopped)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	equals(t, 2, attempts["flaky"])
+	equals(t, 3, attempts["broken"])
+	equals(t, 1, len(dead))
+	equals(t, "broken", dead[0].Item.PspReference)
+	equals(t, 3, dead[0].Attempts)
+	equals(t, "ledger is not available", dead[0].LastError)
+	equals(t, 0, q.Len())
+}
+
+func TestNotificationHandlerQueue(t *testing.T) {
+	q := NewMemoryNotificationQueue()
+	h := NewNotificationHandler(
+		NewWithHMAC(Testing, "username", "password", testNotificationHMAC),
+		WithNotificationQueue(q),
+	)
+
+	processed := make(chan string, 1)
+	h.Handle(EventCodeAuthorisation, func(ctx context.Context, item NotificationRequestItemData) error {
+		processed <- item.PspReference
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(testNotificationJSON)))
+
+	equals(t, http.StatusOK, rec.Code)
+	equals(t, notificationAccepted, rec.Body.String())
+	equals(t, 1, q.Len())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() { _ = NewNotificationDispatcher(q, h.Process).Run(ctx) }()
+
+	select {
+	case psp := <-processed:
+		equals(t, "7914073381342284", psp)
+	case <-ctx.Done():
+		t.Fatal("queued notification is not processed")
+	}
+}
+
+func TestNotificationDispatcherDefaultRetryPolicy(t *testing.T) {
+	d := NewNotificationDispatcher(NewMemoryNotificationQueue(), nil,
+		WithDispatcherRetryPolicy(RetryPolicy{MaxAttempts: 5}),
+	)
+
+	equals(t, RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: DefaultDispatcherInitialBackoff,
+		MaxBackoff:     DefaultDispatcherMaxBackoff,
+	}, d.retryPolicy)
+}
+
+func TestNotificationDispatcherWithoutDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	q := NewMemoryNotificationQueue()
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "broken"}))
+
+	var reported []error
+	attempts := 0
+	done := make(chan struct{})
+
+	d := NewNotificationDispatcher(q,
+		func(ctx context.Context, item NotificationRequestItemData) error {
+			attempts++
+			if attempts == 4 {
+				close(done)
+			}
+			return errors.New("ledger is not available")
+		},
+		WithDispatcherWorkers(1),
+		WithDispatcherRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		WithDispatcherErrorHandler(func(err error) { reported = append(reported, err) }),
+	)
+
+	stopped := make(chan error)
+	runCtx, stop := context.WithCancel(ctx)
+	go func() { stopped <- d.Run(runCtx) }()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("notification is not retried after max attempts")
+	}
+
+	stop()
+	<-stopped
+
+	// item is kept in the queue, failures after max attempts are reported
+	equals(t, 1, q.Len())
+	assert(t, len(reported) >= 2, "expected failures after max attempts to be reported")
+}
+
+func TestNotificationDispatcherShutdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	q := NewMemoryNotificationQueue()
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "slow"}))
+
+	started := make(chan struct{})
+	d := NewNotificationDispatcher(q,
+		func(ctx context.Context, item NotificationRequestItemData) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		WithDispatcherWorkers(1),
+		WithDispatcherRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithDeadLetter(func(ctx context.Context, n *QueuedNotification, err error) {
+			t.Errorf("notification %s should not be dead-lettered on shutdown", n.Item.PspReference)
+		}),
+	)
+
+	stopped := make(chan error)
+	runCtx, stop := context.WithCancel(ctx)
+	go func() { stopped <- d.Run(runCtx) }()
+
+	<-started
+	stop()
+	<-stopped
+
+	// interrupted attempt is not counted and item is available right away
+	n, err := q.Pop(ctx)
+	equals(t, nil, err)
+	equals(t, "slow", n.Item.PspReference)
+	equals(t, 0, n.Attempts)
+}
@@ -0,0 +1,257 @@
+package adyen
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// notificationAccepted - response body Adyen expects to mark notification as delivered
+//
+// Link - https://docs.adyen.com/development-resources/webhooks#accept-notifications
+const notificationAccepted = "[accepted]"
+
+// NotificationCallback - function to process a single notification item
+//
+// Returned error prevents notification from being accepted, so Adyen would deliver it again later
+type NotificationCallback func(ctx context.Context, item NotificationRequestItemData) error
+
+// NotificationHandler - http.Handler to receive Adyen standard notifications
+//
+// For every NotificationRequest handler:
+//
+//   - checks basic authentication, if configured with WithBasicAuth
+//   - validates HMAC signature of every item, unless WithoutSignatureValidation is used
+//   - skips items already processed, if configured with WithNotificationStore
+//   - dispatches every item to a callback registered for its event code
+//   - responds with "[accepted]" only after all callbacks succeeded
+//
+// If configured with WithNotificationQueue, items are only pushed to the queue and
+// processed asynchronously by NotificationDispatcher, see Process
+//
+// Example:
+//
+//	h := adyen.NewNotificationHandler(instance, adyen.WithBasicAuth("user", "pass"))
+//	h.Handle(adyen.EventCodeAuthorisation, func(ctx context.Context, item adyen.NotificationRequestItemData) error {
+//		return orders.MarkPaid(ctx, item.MerchantReference)
+//	})
+//	http.Handle("/adyen/notifications", h)
+type NotificationHandler struct {
+	adyen           *Adyen
+	username        string
+	password        string
+	skipSignature   bool
+	verifier        *NotificationVerifier
+	store           NotificationStore
+	queue           NotificationQueue
+	callbacks       map[EventCode]NotificationCallback
+	defaultCallback NotificationCallback
+}
+
+// NotificationHandlerOption allows for custom configuration of NotificationHandler.
+type NotificationHandlerOption func(*NotificationHandler)
+
+// WithBasicAuth requires notifications to be sent with given basic authentication credentials,
+// as configured for the notification endpoint in Adyen Customer Area.
+func WithBasicAuth(username, password string) NotificationHandlerOption {
+	return func(h *NotificationHandler) {
+		h.username = username
+		h.password = password
+	}
+}
+
+// WithoutSignatureValidation disables HMAC signature validation of notification items.
+//
+// NOTE: use it only if HMAC signature is not configured for the notification endpoint
+func WithoutSignatureValidation() NotificationHandlerOption {
+	return func(h *NotificationHandler) {
+		h.skipSignature = true
+	}
+}
+
+// WithNotificationVerifier sets verifier for HMAC signatures of notification items,
+// f.e. to accept several keys during key rotation.
+func WithNotificationVerifier(v *NotificationVerifier) NotificationHandlerOption {
+	return func(h *NotificationHandler) {
+		h.verifier = v
+	}
+}
+
+// WithNotificationStore makes callbacks run once per unique notification event, see NotificationKey.
+//
+// Duplicate deliveries of already processed items are accepted without calling callbacks
+func WithNotificationStore(s NotificationStore) NotificationHandlerOption {
+	return func(h *NotificationHandler) {
+		h.store = s
+	}
+}
+
+// WithNotificationQueue makes handler accept notifications as soon as they are pushed to the queue.
+//
+// Queued items have to be processed with NotificationDispatcher, using NotificationHandler.Process
+func WithNotificationQueue(q NotificationQueue) NotificationHandlerOption {
+	return func(h *NotificationHandler) {
+		h.queue = q
+	}
+}
+
+// NewNotificationHandler - creates NotificationHandler instance
+//
+// HMAC keys to validate notification items are taken from Adyen instance credentials,
+// see NewWithHMAC and WithHMACVerificationKey, unless configured with WithNotificationVerifier
+func NewNotificationHandler(a *Adyen, opts ...NotificationHandlerOption) *NotificationHandler {
+	h := &NotificationHandler{
+		adyen:     a,
+		callbacks: make(map[EventCode]NotificationCallback),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle registers callback for a given event code, f.e. EventCodeAuthorisation
+func (h *NotificationHandler) Handle(eventCode EventCode, cb NotificationCallback) {
+	h.callbacks[eventCode] = cb
+}
+
+// HandleDefault registers callback for all event codes without own callback
+//
+// Items without any callback are accepted without processing
+func (h *NotificationHandler) HandleDefault(cb NotificationCallback) {
+	h.defaultCallback = cb
+}
+
+// ServeHTTP - handle notification request from Adyen
+func (h *NotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="adyen"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var req NotificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid notification request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	for _, item := range req.NotificationItems {
+		if err := h.accept(r.Context(), item.NotificationRequestItem); err != nil {
+			http.Error(w, fmt.Sprintf("notification %s is not processed: %v", item.NotificationRequestItem.PspReference, err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	_, _ = w.Write([]byte(notificationAccepted))
+}
+
+// authorized - checks basic authentication credentials, if configured
+func (h *NotificationHandler) authorized(r *http.Request) bool {
+	if h.username == "" && h.password == "" {
+		return true
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(h.username)) == 1
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(h.password)) == 1
+
+	return usernameMatch && passwordMatch
+}
+
+// validate - validates HMAC signature of every notification item
+func (h *NotificationHandler) validate(req *NotificationRequest) error {
+	if h.skipSignature {
+		return nil
+	}
+
+	v := h.verifier
+	if v == nil {
+		var err error
+		if v, err = h.adyen.NotificationVerifier(); err != nil {
+			return fmt.Errorf("notification signature can't be validated: %v", err)
+		}
+	}
+
+	return v.VerifyRequest(req)
+}
+
+// accept - pushes notification item to the queue if configured, otherwise processes it right away
+func (h *NotificationHandler) accept(ctx context.Context, item NotificationRequestItemData) error {
+	if h.queue != nil {
+		return h.queue.Push(ctx, item)
+	}
+
+	return h.Process(ctx, item)
+}
+
+// Process - dispatches notification item to a registered callback
+//
+// If notification store is configured, callback is called once per unique event.
+// Process is called by ServeHTTP, or by NotificationDispatcher if handler is used with WithNotificationQueue
+func (h *NotificationHandler) Process(ctx context.Context, item NotificationRequestItemData) error {
+	if h.store == nil {
+		return h.dispatch(ctx, item)
+	}
+
+	key := item.Key()
+	claimed, err := h.store.Begin(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	if err := h.dispatch(ctx, item); err != nil {
+		if rerr := h.store.Release(ctx, key); rerr != nil {
+			return fmt.Errorf("%v (release failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	return h.store.Complete(ctx, key)
+}
+
+// dispatch - passes notification item to a callback registered for its event code
+//
+// Callback panic is returned as an error, so the claim of notification store is released
+// and notification is not accepted
+func (h *NotificationHandler) dispatch(ctx context.Context, item NotificationRequestItemData) (err error) {
+	cb, ok := h.callbacks[item.EventCode]
+	if !ok {
+		cb = h.defaultCallback
+	}
+
+	if cb == nil {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("notification callback panicked: %v", r)
+		}
+	}()
+
+	return cb(ctx, item)
+}
@@ -0,0 +1,276 @@
+package adyen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testNotificationHMAC - HMAC key used to sign testNotificationJSON
+const testNotificationHMAC = "44782DEF547AAA06C910C43932B1EB0C71FC68D9D0C057550C48EC2ACF6BA056"
+
+// testNotificationJSON - notification request with a single item, signed with testNotificationHMAC
+//
+// ref: https://github.com/Adyen/adyen-ruby-api-library/blob/53d9a03ab09d58927ec34e65d3d2acc1c5dc1ea7/spec/utils/hmac_validator_spec.rb
+const testNotificationJSON = `
+{
+	"live": "false",
+	"notificationItems": [
+		{
+			"NotificationRequestItem": {
+				"additionalData": {
+					"authCode": "1234",
+					"cardSummary": "7777",
+					"hmacSignature": "coqCmt/IZ4E3CzPvMY8zTjQVL5hYJUiBRg8UU+iCWo0="
+				},
+				"amount": {
+					"currency": "EUR",
+					"value": 1130
+				},
+				"eventCode": "AUTHORISATION",
+				"eventDate": "2020-01-01T10:00:00+05:00",
+				"merchantAccountCode": "TestMerchant",
+				"merchantReference": "TestPayment-1407325143704",
+				"operations": ["CANCEL", "CAPTURE", "REFUND"],
+				"paymentMethod": "visa",
+				"pspReference": "7914073381342284",
+				"reason": "1234:7777:12\/2012",
+				"success": "true"
+			}
+		}
+	]
+}
+`
+
+func TestNotificationHandler(t *testing.T) {
+	errCallback := errors.New("callback failed")
+
+	cases := []struct {
+		name        string
+		method      string
+		body        string
+		hmacKey     string
+		opts        []NotificationHandlerOption
+		auth        []string
+		callbackErr error
+		expStatus   int
+		expBody     string
+		expCalls    int
+	}{
+		{
+			name:      "accepted",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			hmacKey:   testNotificationHMAC,
+			expStatus: http.StatusOK,
+			expBody:   notificationAccepted,
+			expCalls:  1,
+		},
+		{
+			name:      "accepted with basic auth",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			hmacKey:   testNotificationHMAC,
+			opts:      []NotificationHandlerOption{WithBasicAuth("user", "pass")},
+			auth:      []string{"user", "pass"},
+			expStatus: http.StatusOK,
+			expBody:   notificationAccepted,
+			expCalls:  1,
+		},
+		{
+			name:      "wrong basic auth",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			hmacKey:   testNotificationHMAC,
+			opts:      []NotificationHandlerOption{WithBasicAuth("user", "pass")},
+			auth:      []string{"user", "wrong"},
+			expStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "missing basic auth",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			hmacKey:   testNotificationHMAC,
+			opts:      []NotificationHandlerOption{WithBasicAuth("user", "pass")},
+			expStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "wrong HMAC key",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			hmacKey:   "DFB1EB5485895CFA84146406857104ABB4CBCABDC8AAF103A624C8F6A3EAAB00",
+			expStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "no HMAC key",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			expStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "signature validation disabled",
+			method:    http.MethodPost,
+			body:      testNotificationJSON,
+			opts:      []NotificationHandlerOption{WithoutSignatureValidation()},
+			expStatus: http.StatusOK,
+			expBody:   notificationAccepted,
+			expCalls:  1,
+		},
+		{
+			name:        "callback error",
+			method:      http.MethodPost,
+			body:        testNotificationJSON,
+			hmacKey:     testNotificationHMAC,
+			callbackErr: errCallback,
+			expStatus:   http.StatusInternalServerError,
+			expCalls:    1,
+		},
+		{
+			name:      "malformed request",
+			method:    http.MethodPost,
+			body:      `{"notificationItems": [`,
+			hmacKey:   testNotificationHMAC,
+			expStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "wrong method",
+			method:    http.MethodGet,
+			hmacKey:   testNotificationHMAC,
+			expStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			instance := NewWithHMAC(Testing, "username", "password", c.hmacKey)
+			h := NewNotificationHandler(instance, c.opts...)
+
+			var calls int
+			h.Handle(EventCodeAuthorisation, func(ctx context.Context, item NotificationRequestItemData) error {
+				calls++
+				equals(t, "7914073381342284", item.PspReference)
+				return c.callbackErr
+			})
+			h.HandleDefault(func(ctx context.Context, item NotificationRequestItemData) error {
+				t.Fatalf("default callback should not be called for %s event", item.EventCode)
+				return nil
+			})
+
+			req := httptest.NewRequest(c.method, "/notifications", strings.NewReader(c.body))
+			if c.auth != nil {
+				req.SetBasicAuth(c.auth[0], c.auth[1])
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			equals(t, c.expStatus, rec.Code)
+			equals(t, c.expCalls, calls)
+			if c.expBody != "" {
+				equals(t, c.expBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotificationHandlerDefaultCallback(t *testing.T) {
+	h := NewNotificationHandler(NewWithHMAC(Testing, "username", "password", testNotificationHMAC))
+
+	var events []EventCode
+	h.HandleDefault(func(ctx context.Context, item NotificationRequestItemData) error {
+		events = append(events, item.EventCode)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(testNotificationJSON)))
+
+	equals(t, http.StatusOK, rec.Code)
+	equals(t, []EventCode{EventCodeAuthorisation}, events)
+}
+
+func TestNotificationHandlerStore(t *testing.T) {
+	store := NewMemoryNotificationStore()
+	h := NewNotificationHandler(
+		NewWithHMAC(Testing, "username", "password", testNotificationHMAC),
+		WithNotificationStore(store),
+	)
+
+	calls := 0
+	fail := true
+	h.Handle(EventCodeAuthorisation, func(ctx context.Context, item NotificationRequestItemData) error {
+		calls++
+		if fail {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	deliver := func() int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(testNotificationJSON)))
+		return rec.Code
+	}
+
+	// failed delivery is released and processed again on retry
+	equals(t, http.StatusInternalServerError, deliver())
+	fail = false
+	equals(t, http.StatusOK, deliver())
+	equals(t, 2, calls)
+
+	// duplicate delivery is accepted without calling callback
+	equals(t, http.StatusOK, deliver())
+	equals(t, 2, calls)
+
+	key := NotificationKey{PspReference: "7914073381342284", EventCode: EventCodeAuthorisation, Success: true}
+	assert(t, store.Processed(key), "expected notification to be marked as processed")
+}
+
+func TestNotificationHandlerStoreCallbackPanic(t *testing.T) {
+	store := NewMemoryNotificationStore()
+	h := NewNotificationHandler(
+		NewWithHMAC(Testing, "username", "password", testNotificationHMAC),
+		WithNotificationStore(store),
+	)
+
+	calls := 0
+	h.Handle(EventCodeAuthorisation, func(ctx context.Context, item NotificationRequestItemData) error {
+		calls++
+		if calls == 1 {
+			panic("unexpected nil pointer")
+		}
+		return nil
+	})
+
+	deliver := func() int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(testNotificationJSON)))
+		return rec.Code
+	}
+
+	// panicking callback releases the claim, so redelivery is processed
+	equals(t, http.StatusInternalServerError, deliver())
+	equals(t, http.StatusOK, deliver())
+	equals(t, 2, calls)
+
+	key := NotificationKey{PspReference: "7914073381342284", EventCode: EventCodeAuthorisation, Success: true}
+	assert(t, store.Processed(key), "expected notification to be marked as processed")
+}
+
+func TestNotificationHandlerKeyRotation(t *testing.T) {
+	v, err := NewNotificationVerifier(testNotificationNewHMAC, testNotificationHMAC)
+	equals(t, nil, err)
+
+	h := NewNotificationHandler(
+		NewWithHMAC(Testing, "username", "password", testNotificationNewHMAC),
+		WithNotificationVerifier(v),
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notifications", strings.NewReader(testNotificationJSON)))
+
+	equals(t, http.StatusOK, rec.Code)
+}
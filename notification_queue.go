@@ -0,0 +1,188 @@
+package adyen
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// QueuedNotification - notification item stored in NotificationQueue
+//
+// Description:
+//
+//   - ID - queue specific identifier of the item
+//   - Item - notification item as received from Adyen
+//   - Attempts - number of failed processing attempts so far
+//   - LastError - error message of the last failed attempt
+type QueuedNotification struct {
+	ID        string
+	Item      NotificationRequestItemData
+	Attempts  int
+	LastError string
+}
+
+// NotificationQueue - durable queue of notification items for asynchronous processing
+//
+// Queue provides at-least-once delivery:
+//
+//   - Push stores item, it must not be lost once Push returned without an error
+//   - Pop blocks until item is available or ctx is done, popped item is hidden from other consumers
+//   - Ack removes popped item from the queue
+//   - Retry makes popped item available again not earlier than at a given time
+//
+// Persistent implementations should make items, that are popped but never acknowledged
+// (f.e. after a crash), available again after some visibility timeout
+type NotificationQueue interface {
+	Push(ctx context.Context, item NotificationRequestItemData) error
+	Pop(ctx context.Context) (*QueuedNotification, error)
+	Ack(ctx context.Context, n *QueuedNotification) error
+	Retry(ctx context.Context, n *QueuedNotification, at time.Time) error
+}
+
+// errUnknownQueuedNotification - item is not popped from the queue
+var errUnknownQueuedNotification = errors.New("notification is not in progress")
+
+// MemoryNotificationQueue - in-memory NotificationQueue
+//
+// Items are lost on restart, use persistent storage for production setups
+type MemoryNotificationQueue struct {
+	mu       sync.Mutex
+	seq      int
+	pending  []memoryQueueEntry
+	inflight map[string]*QueuedNotification
+	notify   chan struct{}
+}
+
+type memoryQueueEntry struct {
+	n         *QueuedNotification
+	notBefore time.Time
+}
+
+// NewMemoryNotificationQueue - creates MemoryNotificationQueue instance
+func NewMemoryNotificationQueue() *MemoryNotificationQueue {
+	return &MemoryNotificationQueue{
+		inflight: make(map[string]*QueuedNotification),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Push - adds item to the end of the queue
+func (q *MemoryNotificationQueue) Push(ctx context.Context, item NotificationRequestItemData) error {
+	q.mu.Lock()
+	q.seq++
+	q.pending = append(q.pending, memoryQueueEntry{
+		n: &QueuedNotification{ID: strconv.Itoa(q.seq), Item: item},
+	})
+	q.mu.Unlock()
+
+	q.signal()
+
+	return nil
+}
+
+// Pop - returns the first available item, blocks until there is one or ctx is done
+func (q *MemoryNotificationQueue) Pop(ctx context.Context) (*QueuedNotification, error) {
+	for {
+		n, wake := q.next(time.Now())
+		if n != nil {
+			return n, nil
+		}
+
+		if err := q.wait(ctx, wake); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Ack - removes popped item from the queue
+func (q *MemoryNotificationQueue) Ack(ctx context.Context, n *QueuedNotification) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.inflight[n.ID]; !ok {
+		return errUnknownQueuedNotification
+	}
+	delete(q.inflight, n.ID)
+
+	return nil
+}
+
+// Retry - returns popped item to the queue, item is available again at a given time
+func (q *MemoryNotificationQueue) Retry(ctx context.Context, n *QueuedNotification, at time.Time) error {
+	q.mu.Lock()
+	if _, ok := q.inflight[n.ID]; !ok {
+		q.mu.Unlock()
+		return errUnknownQueuedNotification
+	}
+	delete(q.inflight, n.ID)
+	q.pending = append(q.pending, memoryQueueEntry{n: n, notBefore: at})
+	q.mu.Unlock()
+
+	q.signal()
+
+	return nil
+}
+
+// Len - returns number of items in the queue, including popped but not acknowledged ones
+func (q *MemoryNotificationQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending) + len(q.inflight)
+}
+
+// next - pops the first available item, otherwise returns time, when the next item becomes available
+func (q *MemoryNotificationQueue) next(now time.Time) (*QueuedNotification, time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var wake time.Time
+	for i, e := range q.pending {
+		if e.notBefore.After(now) {
+			if wake.IsZero() || e.notBefore.Before(wake) {
+				wake = e.notBefore
+			}
+			continue
+		}
+
+		q.pending = append(q.pending[:i], q.pending[i+1:]...)
+		q.inflight[e.n.ID] = e.n
+		if len(q.pending) > 0 {
+			// wake up another consumer for the rest of the queue
+			q.signal()
+		}
+
+		return e.n, time.Time{}
+	}
+
+	return nil, wake
+}
+
+// wait - blocks until queue is changed, wake time is reached or ctx is done
+func (q *MemoryNotificationQueue) wait(ctx context.Context, wake time.Time) error {
+	var timer <-chan time.Time
+	if !wake.IsZero() {
+		t := time.NewTimer(time.Until(wake))
+		defer t.Stop()
+		timer = t.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.notify:
+	case <-timer:
+	}
+
+	return nil
+}
+
+// signal - wakes up a consumer waiting in Pop
+func (q *MemoryNotificationQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,80 @@
+package adyen
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryNotificationQueue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	q := NewMemoryNotificationQueue()
+
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "1"}))
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "2"}))
+
+	first, err := q.Pop(ctx)
+	equals(t, nil, err)
+	equals(t, "1", first.Item.PspReference)
+
+	equals(t, nil, q.Retry(ctx, first, time.Now().Add(time.Hour)))
+
+	second, err := q.Pop(ctx)
+	equals(t, nil, err)
+	equals(t, "2", second.Item.PspReference)
+	equals(t, nil, q.Ack(ctx, second))
+	assert(t, q.Ack(ctx, second) != nil, "expected acknowledged item not to be acknowledged twice")
+
+	// only delayed item is left
+	equals(t, 1, q.Len())
+
+	popCtx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer cancel()
+	_, err = q.Pop(popCtx)
+	equals(t, context.DeadlineExceeded, err)
+}
+
+func TestMemoryNotificationQueueDelayedItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	q := NewMemoryNotificationQueue()
+
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "1"}))
+	n, err := q.Pop(ctx)
+	equals(t, nil, err)
+
+	equals(t, nil, q.Retry(ctx, n, time.Now().Add(time.Millisecond*20)))
+
+	popCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	start := time.Now()
+	n, err = q.Pop(popCtx)
+	equals(t, nil, err)
+	equals(t, "1", n.Item.PspReference)
+	assert(t, time.Since(start) >= time.Millisecond*15, "expected item to be delayed")
+}
+
+func TestMemoryNotificationQueueBlockingPop(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	q := NewMemoryNotificationQueue()
+	popped := make(chan *QueuedNotification)
+	go func() {
+		n, _ := q.Pop(ctx)
+		popped <- n
+	}()
+
+	time.Sleep(time.Millisecond * 10)
+	equals(t, nil, q.Push(ctx, NotificationRequestItemData{PspReference: "1"}))
+
+	n := <-popped
+	assert(t, n != nil, "expected waiting consumer to receive pushed item")
+	equals(t, "1", n.Item.PspReference)
+}
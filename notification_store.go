@@ -0,0 +1,146 @@
+package adyen
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NotificationKey - identifies a unique notification event
+//
+// Adyen may deliver the same notification item several times, all deliveries share the same key
+type NotificationKey struct {
+	PspReference      string
+	EventCode         EventCode
+	Success           bool
+	OriginalReference string
+}
+
+// String - returns key as a single string, f.e. to be used as a primary key column or a file name
+func (k NotificationKey) String() string {
+	return strings.Join([]string{
+		k.PspReference,
+		string(k.EventCode),
+		strconv.FormatBool(k.Success),
+		k.OriginalReference,
+	}, ":")
+}
+
+// Key - returns deduplication key of notification item
+func (n NotificationRequestItemData) Key() NotificationKey {
+	return NotificationKey{
+		PspReference:      n.PspReference,
+		EventCode:         n.EventCode,
+		Success:           bool(n.Success),
+		OriginalReference: n.OriginalReference,
+	}
+}
+
+// DefaultNotificationLease - default time a claim of a notification key is held by MemoryNotificationStore
+//
+// Claims older than lease are considered abandoned, f.e. by a crashed process, and could be taken over
+const DefaultNotificationLease = 5 * time.Minute
+
+// NotificationStore - keeps track of processed notifications, so every unique event is processed once
+//
+// Processing of a notification item is wrapped as:
+//
+//   - Begin claims the key for a lease; false means the key is already processed or claimed by
+//     another delivery, and the claim is not expired yet
+//   - Complete marks the key as processed after callback succeeded
+//   - Release removes the claim after callback failed or panicked, so the next delivery is processed again
+//
+// If a process crashes before Complete or Release, the claim expires after the lease and
+// the next delivery takes it over. Lease should be longer than the slowest callback.
+//
+// Contract maps to a single table with a unique key column and claim time, f.e. for SQL storage:
+//
+//   - Begin - INSERT INTO notifications (key, status, claimed_at) VALUES ($1, 'processing', now())
+//     ON CONFLICT (key) DO UPDATE SET claimed_at = now()
+//     WHERE notifications.status = 'processing' AND notifications.claimed_at < now() - $lease,
+//     returns true if a row was inserted or updated
+//   - Complete - UPDATE notifications SET status = 'processed' WHERE key = $1
+//   - Release - DELETE FROM notifications WHERE key = $1 AND status = 'processing'
+//
+// Implementations must be safe for concurrent use
+type NotificationStore interface {
+	Begin(ctx context.Context, key NotificationKey) (bool, error)
+	Complete(ctx context.Context, key NotificationKey) error
+	Release(ctx context.Context, key NotificationKey) error
+}
+
+// MemoryNotificationStore - in-memory NotificationStore
+//
+// Keys are never evicted and lost on restart, use persistent storage for production setups
+type MemoryNotificationStore struct {
+	mu    sync.Mutex
+	lease time.Duration
+	now   func() time.Time
+	keys  map[NotificationKey]notificationClaim
+}
+
+// notificationClaim - state of a notification key in MemoryNotificationStore
+type notificationClaim struct {
+	processed bool
+	claimedAt time.Time
+}
+
+// NewMemoryNotificationStore - creates MemoryNotificationStore instance with DefaultNotificationLease
+func NewMemoryNotificationStore() *MemoryNotificationStore {
+	return NewMemoryNotificationStoreWithLease(DefaultNotificationLease)
+}
+
+// NewMemoryNotificationStoreWithLease - creates MemoryNotificationStore instance with a given claim lease
+func NewMemoryNotificationStoreWithLease(lease time.Duration) *MemoryNotificationStore {
+	return &MemoryNotificationStore{
+		lease: lease,
+		now:   time.Now,
+		keys:  make(map[NotificationKey]notificationClaim),
+	}
+}
+
+// Begin - claims the key, returns false if key is processed or claimed and the claim is not expired
+func (s *MemoryNotificationStore) Begin(ctx context.Context, key NotificationKey) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if c, ok := s.keys[key]; ok && (c.processed || now.Sub(c.claimedAt) < s.lease) {
+		return false, nil
+	}
+	s.keys[key] = notificationClaim{claimedAt: now}
+
+	return true, nil
+}
+
+// Complete - marks the key as processed
+func (s *MemoryNotificationStore) Complete(ctx context.Context, key NotificationKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key] = notificationClaim{processed: true}
+
+	return nil
+}
+
+// Release - removes the claim of a key, that is not processed yet
+func (s *MemoryNotificationStore) Release(ctx context.Context, key NotificationKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.keys[key]; ok && !c.processed {
+		delete(s.keys, key)
+	}
+
+	return nil
+}
+
+// Processed - checks if the key is marked as processed
+func (s *MemoryNotificationStore) Processed(key NotificationKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keys[key].processed
+}
@@ -0,0 +1,78 @@
+package adyen
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNotificationKey(t *testing.T) {
+	t.Parallel()
+
+	item := NotificationRequestItemData{
+		PspReference:      "8815555555555555",
+		EventCode:         EventCodeRefund,
+		Success:           true,
+		OriginalReference: "8813333333333333",
+	}
+
+	equals(t, "8815555555555555:REFUND:true:8813333333333333", item.Key().String())
+
+	failed := item
+	failed.Success = false
+	assert(t, item.Key() != failed.Key(), "expected failed event to have a different key")
+}
+
+func TestMemoryNotificationStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryNotificationStore()
+	key := NotificationKey{PspReference: "8815555555555555", EventCode: EventCodeCapture, Success: true}
+
+	claimed, err := store.Begin(ctx, key)
+	equals(t, nil, err)
+	assert(t, claimed, "expected new key to be claimed")
+
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, !claimed, "expected claimed key not to be claimed twice")
+
+	equals(t, nil, store.Release(ctx, key))
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, claimed, "expected released key to be claimed again")
+
+	equals(t, nil, store.Complete(ctx, key))
+	assert(t, store.Processed(key), "expected key to be processed")
+
+	equals(t, nil, store.Release(ctx, key))
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, !claimed, "expected processed key not to be released")
+}
+
+func TestMemoryNotificationStoreLease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	store := NewMemoryNotificationStoreWithLease(time.Minute)
+	store.now = func() time.Time { return now }
+	key := NotificationKey{PspReference: "8815555555555555", EventCode: EventCodeCapture, Success: true}
+
+	claimed, _ := store.Begin(ctx, key)
+	assert(t, claimed, "expected new key to be claimed")
+
+	// claim is abandoned, f.e. process crashed before Complete or Release
+	now = now.Add(59 * time.Second)
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, !claimed, "expected claim not to be taken over within lease")
+
+	now = now.Add(time.Second)
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, claimed, "expected expired claim to be taken over")
+
+	equals(t, nil, store.Complete(ctx, key))
+	now = now.Add(time.Hour)
+	claimed, _ = store.Begin(ctx, key)
+	assert(t, !claimed, "expected processed key never to be claimed again")
+}
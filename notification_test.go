@@ -137,3 +137,117 @@ func TestNotificationRequest(t *testing.T) {
 		t.Errorf("Expected to have successful notification, %t given", item.Success)
 	}
 }
+
+func TestNotificationEventViews(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		item         NotificationRequestItemData
+		chargeback   bool
+		modification bool
+		report       bool
+		recurring    bool
+		authorise    bool
+	}{
+		{
+			name:      "authorisation",
+			item:      NotificationRequestItemData{EventCode: EventCodeAuthorisation},
+			authorise: true,
+		},
+		{
+			name:         "capture",
+			item:         NotificationRequestItemData{EventCode: EventCodeCapture},
+			modification: true,
+		},
+		{
+			name:       "chargeback",
+			item:       NotificationRequestItemData{EventCode: EventCodeChargeback},
+			chargeback: true,
+		},
+		{
+			name:   "report",
+			item:   NotificationRequestItemData{EventCode: EventCodeReportAvailable},
+			report: true,
+		},
+		{
+			name:      "recurring contract",
+			item:      NotificationRequestItemData{EventCode: EventCodeRecurringContract},
+			recurring: true,
+		},
+		{
+			name: "unknown",
+			item: NotificationRequestItemData{EventCode: EventCode("NEW_EVENT")},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := c.item.AsChargeback()
+			equals(t, c.chargeback, ok)
+			_, ok = c.item.AsModification()
+			equals(t, c.modification, ok)
+			_, ok = c.item.AsReportAvailable()
+			equals(t, c.report, ok)
+			_, ok = c.item.AsRecurringContract()
+			equals(t, c.recurring, ok)
+			_, ok = c.item.AsAuthorisation()
+			equals(t, c.authorise, ok)
+		})
+	}
+}
+
+func TestNotificationAsChargeback(t *testing.T) {
+	t.Parallel()
+
+	item := NotificationRequestItemData{
+		EventCode:         EventCodeNotificationOfChargeback,
+		PspReference:      "9915555555555555",
+		OriginalReference: "9913333333333333",
+		Amount:            Amount{Value: 1000, Currency: "EUR"},
+		Reason:            "Fraudulent Processing of Transactions",
+	}
+	item.AdditionalData.NOFReasonCode = "10.4"
+	item.AdditionalData.ChargebackReasonCode = "other"
+
+	chargeback, ok := item.AsChargeback()
+	assert(t, ok, "expected NOTIFICATION_OF_CHARGEBACK to be a chargeback")
+	equals(t, "10.4", chargeback.ReasonCode)
+	equals(t, "9913333333333333", chargeback.OriginalReference)
+	equals(t, int64(1000), chargeback.Amount.Value)
+}
+
+func TestNotificationAsReportAvailable(t *testing.T) {
+	t.Parallel()
+
+	item := NotificationRequestItemData{
+		EventCode:           EventCodeReportAvailable,
+		MerchantAccountCode: "TestMerchant",
+		PspReference:        "settlement_detail_report_batch_1.csv",
+		Reason:              "https://ca-test.adyen.com/reports/download/MerchantAccount/TestMerchant/settlement_detail_report_batch_1.csv",
+	}
+
+	report, ok := item.AsReportAvailable()
+	assert(t, ok, "expected REPORT_AVAILABLE view")
+	equals(t, "settlement_detail_report_batch_1.csv", report.ReportName)
+	equals(t, item.Reason, report.ReportURL)
+}
+
+func TestNotificationAsRecurringContract(t *testing.T) {
+	t.Parallel()
+
+	item := NotificationRequestItemData{
+		EventCode:         EventCodeRecurringContract,
+		PspReference:      "8315555555555555",
+		OriginalReference: "8313333333333333",
+		PaymentMethod:     "visa",
+	}
+	item.AdditionalData.ShopperReference = "shopper-1"
+
+	contract, ok := item.AsRecurringContract()
+	assert(t, ok, "expected RECURRING_CONTRACT view")
+	equals(t, "8315555555555555", contract.RecurringDetailReference)
+	equals(t, "8313333333333333", contract.OriginalReference)
+	equals(t, "shopper-1", contract.ShopperReference)
+}
@@ -0,0 +1,169 @@
+package adyen
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HmacSignatureHeader - header with HMAC signature of raw request body,
+// used by management and platform webhooks
+//
+// Link - https://docs.adyen.com/development-resources/webhooks/verify-hmac-signatures#verify-using-your-own-solution
+const HmacSignatureHeader = "HmacSignature"
+
+var (
+	errNoHMACKey        = errors.New("no HMAC key configured; cannot validate signature")
+	errNoHMACSignature  = errors.New("no HMAC signature in message")
+	errInvalidSignature = errors.New("invalid HMAC signature")
+)
+
+// NotificationVerifier - validates HMAC signatures of notifications and webhooks
+//
+// Several keys could be active at the same time, f.e. during key rotation:
+// signature is valid if it matches any of them. Signatures are compared in constant time
+type NotificationVerifier struct {
+	keys [][]byte
+}
+
+// NotificationItemFailure - notification item, that failed signature validation
+type NotificationItemFailure struct {
+	Index        int
+	PspReference string
+	Err          error
+}
+
+// NotificationVerificationError - list of notification items, that failed signature validation
+type NotificationVerificationError struct {
+	Failures []NotificationItemFailure
+}
+
+// Error - error interface implementation
+func (e *NotificationVerificationError) Error() string {
+	items := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		items = append(items, fmt.Sprintf("notification %s: %v", f.PspReference, f.Err))
+	}
+
+	return strings.Join(items, "; ")
+}
+
+// NewNotificationVerifier - creates NotificationVerifier with hex-encoded HMAC keys,
+// as generated in Adyen Customer Area
+func NewNotificationVerifier(hexKeys ...string) (*NotificationVerifier, error) {
+	v := &NotificationVerifier{}
+
+	for _, k := range hexKeys {
+		if k == "" {
+			continue
+		}
+
+		key, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = append(v.keys, key)
+	}
+
+	if len(v.keys) == 0 {
+		return nil, errNoHMACKey
+	}
+
+	return v, nil
+}
+
+// VerifyItem - validates HMAC signature of a standard notification item
+func (v *NotificationVerifier) VerifyItem(n *NotificationRequestItemData) error {
+	signature := n.AdditionalData.HmacSignature
+	if signature == "" {
+		return errNoHMACSignature
+	}
+
+	return v.verify([]byte(notificationSigningString(n)), signature)
+}
+
+// VerifyRequest - validates HMAC signatures of all items of a standard notification request
+//
+// Returned *NotificationVerificationError lists every failed item
+func (v *NotificationVerifier) VerifyRequest(req *NotificationRequest) error {
+	var failures []NotificationItemFailure
+
+	for i := range req.NotificationItems {
+		item := &req.NotificationItems[i].NotificationRequestItem
+		if err := v.VerifyItem(item); err != nil {
+			failures = append(failures, NotificationItemFailure{Index: i, PspReference: item.PspReference, Err: err})
+		}
+	}
+
+	if len(failures) > 0 {
+		return &NotificationVerificationError{Failures: failures}
+	}
+
+	return nil
+}
+
+// VerifyPayload - validates base64 encoded HMAC signature of a raw webhook body,
+// f.e. taken from HmacSignatureHeader
+func (v *NotificationVerifier) VerifyPayload(body []byte, signature string) error {
+	if signature == "" {
+		return errNoHMACSignature
+	}
+
+	return v.verify(body, signature)
+}
+
+// VerifyHTTPRequest - validates webhook request signed with HmacSignatureHeader and returns its raw body
+//
+// Request body is consumed and replaced, so it could be read again
+func (v *NotificationVerifier) VerifyHTTPRequest(r *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := v.VerifyPayload(body, r.Header.Get(HmacSignatureHeader)); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// verify - checks signature of data with every configured key
+func (v *NotificationVerifier) verify(data []byte, signature string) error {
+	provided := []byte(signature)
+
+	for _, key := range v.keys {
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write(data)
+		expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+		if hmac.Equal([]byte(expected), provided) {
+			return nil
+		}
+	}
+
+	return errInvalidSignature
+}
+
+// notificationSigningString - builds string to sign for standard notification item
+func notificationSigningString(n *NotificationRequestItemData) string {
+	return strings.Join([]string{
+		replaceSpecialChars(n.PspReference),
+		replaceSpecialChars(n.OriginalReference),
+		replaceSpecialChars(n.MerchantAccountCode),
+		replaceSpecialChars(n.MerchantReference),
+		strconv.FormatInt(n.Amount.Value, 10),
+		replaceSpecialChars(n.Amount.Currency),
+		replaceSpecialChars(string(n.EventCode)),
+		strconv.FormatBool(bool(n.Success)),
+	}, ":")
+}
@@ -0,0 +1,103 @@
+package adyen
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testNotificationNewHMAC - HMAC key rotated in, that doesn't match signature of testNotificationJSON
+//
+// In key rotation scenarios testNotificationHMAC is the old key, testNotificationJSON is still signed with
+const testNotificationNewHMAC = "DFB1EB5485895CFA84146406857104ABB4CBCABDC8AAF103A624C8F6A3EAAB00"
+
+func TestNewNotificationVerifier(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewNotificationVerifier()
+	equals(t, errNoHMACKey, err)
+
+	_, err = NewNotificationVerifier("", "")
+	equals(t, errNoHMACKey, err)
+
+	_, err = NewNotificationVerifier(testNotificationHMAC, "not-a-hex-key")
+	assert(t, err != nil, "expected malformed key to be rejected")
+}
+
+func TestNotificationVerifierRequest(t *testing.T) {
+	t.Parallel()
+
+	var req NotificationRequest
+	if err := json.Unmarshal([]byte(testNotificationJSON), &req); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	tampered := req.NotificationItems[0]
+	tampered.NotificationRequestItem.Amount.Value = 1
+	unsigned := req.NotificationItems[0]
+	unsigned.NotificationRequestItem.AdditionalData.HmacSignature = ""
+	req.NotificationItems = append(req.NotificationItems, tampered, unsigned)
+
+	cases := []struct {
+		name     string
+		keys     []string
+		failures []int
+	}{
+		{name: "single key", keys: []string{testNotificationHMAC}, failures: []int{1, 2}},
+		{name: "rotated keys", keys: []string{testNotificationNewHMAC, testNotificationHMAC}, failures: []int{1, 2}},
+		{name: "wrong key", keys: []string{testNotificationNewHMAC}, failures: []int{0, 1, 2}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			v, err := NewNotificationVerifier(c.keys...)
+			equals(t, nil, err)
+
+			verr, ok := v.VerifyRequest(&req).(*NotificationVerificationError)
+			assert(t, ok, "expected verification error")
+
+			var failed []int
+			for _, f := range verr.Failures {
+				failed = append(failed, f.Index)
+			}
+			equals(t, c.failures, failed)
+			equals(t, errNoHMACSignature, verr.Failures[len(verr.Failures)-1].Err)
+		})
+	}
+}
+
+func TestNotificationVerifierPayload(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"type":"merchant.updated","data":{"merchantId":"TestMerchant"}}`)
+
+	key, _ := hex.DecodeString(testNotificationHMAC)
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	v, err := NewNotificationVerifier(testNotificationNewHMAC, testNotificationHMAC)
+	equals(t, nil, err)
+
+	equals(t, nil, v.VerifyPayload(body, signature))
+	equals(t, errInvalidSignature, v.VerifyPayload(append(body, ' '), signature))
+	equals(t, errNoHMACSignature, v.VerifyPayload(body, ""))
+
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(string(body)))
+	r.Header.Set(HmacSignatureHeader, signature)
+
+	raw, err := v.VerifyHTTPRequest(r)
+	equals(t, nil, err)
+	equals(t, body, raw)
+
+	again, _ := ioutil.ReadAll(r.Body)
+	equals(t, body, again)
+}
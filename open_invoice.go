@@ -0,0 +1,182 @@
+package adyen
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Tax categories of a line item
+const (
+	TaxCategoryHigh = "High"
+	TaxCategoryLow  = "Low"
+	TaxCategoryNone = "None"
+	TaxCategoryZero = "Zero"
+)
+
+// openInvoicePrefix - prefix of open invoice fields in additional data and HPP requests
+const openInvoicePrefix = "openinvoicedata."
+
+// Validate - checks that line item amounts are consistent
+//
+// Amount including tax has to be equal to amount excluding tax plus tax amount,
+// tax amount has to match tax percentage, rounding difference of one minor unit is allowed
+func (l LineItem) Validate() error {
+	if l.Quantity <= 0 {
+		return fmt.Errorf("line item %q: quantity should be positive", l.ID)
+	}
+
+	if l.AmountIncludingTax == 0 && l.AmountExcludingTax == 0 {
+		return fmt.Errorf("line item %q: amount is not specified", l.ID)
+	}
+
+	if l.AmountIncludingTax != 0 && l.AmountExcludingTax != 0 && l.AmountIncludingTax != l.AmountExcludingTax+l.TaxAmount {
+		return fmt.Errorf("line item %q: amount including tax %d doesn't match amount excluding tax %d and tax %d",
+			l.ID, l.AmountIncludingTax, l.AmountExcludingTax, l.TaxAmount)
+	}
+
+	if l.TaxPercentage != 0 {
+		expected := roundDiv(l.amountExcludingTax()*l.TaxPercentage, 10000)
+		if diff := l.TaxAmount - expected; diff > 1 || diff < -1 {
+			return fmt.Errorf("line item %q: tax amount %d doesn't match tax percentage %d, expected %d",
+				l.ID, l.TaxAmount, l.TaxPercentage, expected)
+		}
+	}
+
+	return nil
+}
+
+// Total - returns total amount of the line including tax
+func (l LineItem) Total() int64 {
+	return l.Quantity * l.amountIncludingTax()
+}
+
+// amountIncludingTax - returns unit amount including tax, calculated if not specified
+func (l LineItem) amountIncludingTax() int64 {
+	if l.AmountIncludingTax != 0 {
+		return l.AmountIncludingTax
+	}
+
+	return l.AmountExcludingTax + l.TaxAmount
+}
+
+// amountExcludingTax - returns unit amount excluding tax, calculated if not specified
+func (l LineItem) amountExcludingTax() int64 {
+	if l.AmountExcludingTax != 0 {
+		return l.AmountExcludingTax
+	}
+
+	return l.AmountIncludingTax - l.TaxAmount
+}
+
+// ValidateLineItems - validates every line item and checks, that lines total matches payment amount
+func ValidateLineItems(amount Amount, items []LineItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("no line items specified")
+	}
+
+	var total int64
+	for _, l := range items {
+		if err := l.Validate(); err != nil {
+			return err
+		}
+		total += l.Total()
+	}
+
+	if total != amount.Value {
+		return fmt.Errorf("line items total %d doesn't match amount %d", total, amount.Value)
+	}
+
+	return nil
+}
+
+// OpenInvoiceData - invoice lines for open invoice payment methods (Klarna, Afterpay, Ratepay),
+// as sent in openinvoicedata.* fields of classic API additional data and HPP requests
+//
+// Signature is set by HPP request CalculateSignature.
+//
+// Link - https://docs.adyen.com/developers/payment-methods/open-invoice-payment-methods
+type OpenInvoiceData struct {
+	Currency  string
+	Lines     []LineItem
+	Signature string
+}
+
+// NewOpenInvoiceData - creates OpenInvoiceData, lines are validated against payment amount
+func NewOpenInvoiceData(amount Amount, items []LineItem) (*OpenInvoiceData, error) {
+	if err := ValidateLineItems(amount, items); err != nil {
+		return nil, err
+	}
+
+	return &OpenInvoiceData{Currency: amount.Currency, Lines: items}, nil
+}
+
+// Fields - returns openinvoicedata.* fields, lines are numbered from 1
+func (d *OpenInvoiceData) Fields() map[string]string {
+	fields := map[string]string{
+		openInvoicePrefix + "numberOfLines": strconv.Itoa(len(d.Lines)),
+	}
+
+	for i, l := range d.Lines {
+		line := openInvoicePrefix + "line" + strconv.Itoa(i+1) + "."
+
+		fields[line+"currencyCode"] = d.Currency
+		fields[line+"description"] = l.Description
+		fields[line+"itemAmount"] = strconv.FormatInt(l.amountExcludingTax(), 10)
+		fields[line+"itemVatAmount"] = strconv.FormatInt(l.TaxAmount, 10)
+		fields[line+"itemVatPercentage"] = strconv.FormatInt(l.TaxPercentage, 10)
+		fields[line+"numberOfItems"] = strconv.FormatInt(l.Quantity, 10)
+		if l.TaxCategory != "" {
+			fields[line+"vatCategory"] = l.TaxCategory
+		}
+		if l.ID != "" {
+			fields[line+"itemId"] = l.ID
+		}
+	}
+
+	return fields
+}
+
+// EncodeValues - adds openinvoicedata.* fields to HPP request, see query.Encoder
+func (d *OpenInvoiceData) EncodeValues(key string, v *url.Values) error {
+	for name, value := range d.Fields() {
+		v.Set(name, value)
+	}
+
+	if d.Signature != "" {
+		v.Set(openInvoicePrefix+"sig", d.Signature)
+	}
+
+	return nil
+}
+
+// sign - calculates signature of openinvoicedata.* fields
+func (d *OpenInvoiceData) sign(hmacKey string) error {
+	sig, err := SignHPPFields(d.Fields(), hmacKey)
+	if err != nil {
+		return err
+	}
+
+	d.Signature = sig
+	return nil
+}
+
+// SetOpenInvoiceData - adds openinvoicedata.* fields to additional data of classic API payment request
+func (d *AdditionalData) SetOpenInvoiceData(data *OpenInvoiceData) {
+	if d.Extra == nil {
+		d.Extra = make(ExtraData)
+	}
+
+	for name, value := range data.Fields() {
+		d.Extra[name] = value
+	}
+}
+
+// roundDiv - divides and rounds half away from zero
+func roundDiv(a, b int64) int64 {
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+
+	return (a + b/2) / b
+}
@@ -0,0 +1,138 @@
+package adyen
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+)
+
+// testInvoiceLines - two shoes and a discount, 21% VAT
+func testInvoiceLines() []LineItem {
+	return []LineItem{
+		{
+			ID:                 "shoes",
+			Description:        "Shoes",
+			Quantity:           2,
+			AmountExcludingTax: 3306,
+			AmountIncludingTax: 4000,
+			TaxAmount:          694,
+			TaxPercentage:      2100,
+			TaxCategory:        TaxCategoryHigh,
+		},
+		{
+			ID:                 "discount",
+			Description:        "Discount",
+			Quantity:           1,
+			AmountExcludingTax: -826,
+			TaxAmount:          -174,
+			TaxPercentage:      2100,
+			TaxCategory:        TaxCategoryHigh,
+		},
+	}
+}
+
+func TestValidateLineItems(t *testing.T) {
+	t.Parallel()
+
+	equals(t, nil, ValidateLineItems(Amount{Value: 7000, Currency: "EUR"}, testInvoiceLines()))
+
+	cases := []struct {
+		name   string
+		amount int64
+		modify func(items []LineItem)
+	}{
+		{name: "total mismatch", amount: 8000, modify: func(items []LineItem) {}},
+		{name: "zero quantity", amount: 7000, modify: func(items []LineItem) { items[0].Quantity = 0 }},
+		{name: "inconsistent tax", amount: 7000, modify: func(items []LineItem) { items[0].AmountExcludingTax = 3000 }},
+		{name: "wrong tax percentage", amount: 7000, modify: func(items []LineItem) { items[0].TaxPercentage = 900 }},
+		{name: "missing amount", amount: 7000, modify: func(items []LineItem) {
+			items[0].AmountIncludingTax, items[0].AmountExcludingTax = 0, 0
+		}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			items := testInvoiceLines()
+			c.modify(items)
+			assert(t, ValidateLineItems(Amount{Value: c.amount, Currency: "EUR"}, items) != nil, "expected line items to be rejected")
+		})
+	}
+}
+
+func TestOpenInvoiceDataAdditionalData(t *testing.T) {
+	t.Parallel()
+
+	data, err := NewOpenInvoiceData(Amount{Value: 7000, Currency: "EUR"}, testInvoiceLines())
+	equals(t, nil, err)
+
+	req := Authorise{AdditionalData: &AdditionalData{}}
+	req.AdditionalData.SetOpenInvoiceData(data)
+
+	body, err := json.Marshal(req)
+	equals(t, nil, err)
+
+	var decoded struct {
+		AdditionalData map[string]string `json:"additionalData"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	equals(t, "2", decoded.AdditionalData["openinvoicedata.numberOfLines"])
+	equals(t, "3306", decoded.AdditionalData["openinvoicedata.line1.itemAmount"])
+	equals(t, "694", decoded.AdditionalData["openinvoicedata.line1.itemVatAmount"])
+	equals(t, "2", decoded.AdditionalData["openinvoicedata.line1.numberOfItems"])
+	equals(t, "-826", decoded.AdditionalData["openinvoicedata.line2.itemAmount"])
+	equals(t, "EUR", decoded.AdditionalData["openinvoicedata.line2.currencyCode"])
+	equals(t, "High", decoded.AdditionalData["openinvoicedata.line2.vatCategory"])
+}
+
+func TestOpenInvoiceDataHPP(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationHMAC)
+
+	data, err := NewOpenInvoiceData(Amount{Value: 7000, Currency: "EUR"}, testInvoiceLines())
+	equals(t, nil, err)
+
+	req := SkipHppRequest{
+		MerchantReference: "order-1",
+		PaymentAmount:     7000,
+		CurrencyCode:      "EUR",
+		SkinCode:          "skin",
+		MerchantAccount:   "TestMerchant",
+		BrandCode:         "klarna",
+		OpenInvoiceData:   data,
+	}
+
+	link, err := instance.Payment().GetHPPRedirectURL(&req)
+	equals(t, nil, err)
+
+	u, err := url.Parse(link)
+	equals(t, nil, err)
+	q := u.Query()
+
+	equals(t, "2", q.Get("openinvoicedata.numberOfLines"))
+	equals(t, "Shoes", q.Get("openinvoicedata.line1.description"))
+
+	invoiceSig, err := SignHPPFields(data.Fields(), testNotificationHMAC)
+	equals(t, nil, err)
+	equals(t, invoiceSig, q.Get("openinvoicedata.sig"))
+
+	// merchantSig covers open invoice fields
+	fields := make(map[string]string, len(q))
+	for name := range q {
+		fields[name] = q.Get(name)
+	}
+	equals(t, nil, instance.verifyHPPFields(fields))
+
+	// request without open invoice data has no openinvoicedata fields
+	req.OpenInvoiceData = nil
+	v, err := query.Values(req)
+	equals(t, nil, err)
+	_, sent := v["openinvoicedata.numberOfLines"]
+	assert(t, !sent, "expected open invoice fields not to be sent")
+}
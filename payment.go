@@ -10,9 +10,17 @@ package adyen
 //
 // Link: https://docs.adyen.com/developers/api-reference/payments-api#recurring
 const (
-	RecurringPaymentOneClick              = "ONECLICK"
-	RecurringPaymentRecurring             = "RECURRING"
-	ShopperInteractionContAuth            = "ContAuth"
+	// RecurringPaymentOneClick - one-click contract
+	//
+	// Deprecated: use ContractOneClick instead.
+	RecurringPaymentOneClick = "ONECLICK"
+
+	// RecurringPaymentRecurring - recurring contract
+	//
+	// Deprecated: use ContractRecurring instead.
+	RecurringPaymentRecurring = "RECURRING"
+
+	// SelectRecurringDetailReferenceLatests - selects the latest stored payment details of a shopper
 	SelectRecurringDetailReferenceLatests = "LATEST"
 )
 
@@ -20,45 +28,45 @@ const (
 //
 // Link - https://docs.adyen.com/developers/api-reference/payments-api#paymentrequest
 type AuthoriseEncrypted struct {
-	AdditionalData                   *AdditionalData `json:"additionalData,omitempty"`
-	Amount                           *Amount         `json:"amount"`
-	BillingAddress                   *Address        `json:"billingAddress,omitempty"`
-	DeliveryAddress                  *Address        `json:"deliveryAddress,omitempty"`
-	Reference                        string          `json:"reference"`
-	MerchantAccount                  string          `json:"merchantAccount"`
-	ShopperReference                 string          `json:"shopperReference,omitempty"` // Mandatory for recurring payment
-	Recurring                        *Recurring      `json:"recurring,omitempty"`
-	ShopperEmail                     string          `json:"shopperEmail,omitempty"`
-	ShopperInteraction               string          `json:"shopperInteraction,omitempty"`
-	ShopperIP                        string          `json:"shopperIP,omitempty"`
-	ShopperLocale                    string          `json:"shopperLocale,omitempty"`
-	ShopperName                      *Name           `json:"shopperName,omitempty"`
-	SelectedRecurringDetailReference string          `json:"selectedRecurringDetailReference,omitempty"`
-	BrowserInfo                      *BrowserInfo    `json:"browserInfo,omitempty"` // Required for a 3DS process
-	CaptureDelayHours                *int            `json:"captureDelayHours,omitempty"`
+	AdditionalData                   *AdditionalData    `json:"additionalData,omitempty"`
+	Amount                           *Amount            `json:"amount"`
+	BillingAddress                   *Address           `json:"billingAddress,omitempty"`
+	DeliveryAddress                  *Address           `json:"deliveryAddress,omitempty"`
+	Reference                        string             `json:"reference"`
+	MerchantAccount                  string             `json:"merchantAccount"`
+	ShopperReference                 string             `json:"shopperReference,omitempty"` // Mandatory for recurring payment
+	Recurring                        *Recurring         `json:"recurring,omitempty"`
+	ShopperEmail                     string             `json:"shopperEmail,omitempty"`
+	ShopperInteraction               ShopperInteraction `json:"shopperInteraction,omitempty"`
+	ShopperIP                        string             `json:"shopperIP,omitempty"`
+	ShopperLocale                    string             `json:"shopperLocale,omitempty"`
+	ShopperName                      *Name              `json:"shopperName,omitempty"`
+	SelectedRecurringDetailReference string             `json:"selectedRecurringDetailReference,omitempty"`
+	BrowserInfo                      *BrowserInfo       `json:"browserInfo,omitempty"` // Required for a 3DS process
+	CaptureDelayHours                *int               `json:"captureDelayHours,omitempty"`
 }
 
 // Authorise structure for Authorisation request (card is not encrypted)
 //
 // Link - https://docs.adyen.com/developers/api-reference/payments-api#paymentrequest
 type Authorise struct {
-	AdditionalData                   *AdditionalData `json:"additionalData,omitempty"`
-	Card                             *Card           `json:"card,omitempty"`
-	Amount                           *Amount         `json:"amount"`
-	BillingAddress                   *Address        `json:"billingAddress,omitempty"`
-	DeliveryAddress                  *Address        `json:"deliveryAddress,omitempty"`
-	Reference                        string          `json:"reference"`
-	MerchantAccount                  string          `json:"merchantAccount"`
-	ShopperReference                 string          `json:"shopperReference,omitempty"` // Mandatory for recurring payment
-	Recurring                        *Recurring      `json:"recurring,omitempty"`
-	ShopperEmail                     string          `json:"shopperEmail,omitempty"`
-	ShopperInteraction               string          `json:"shopperInteraction,omitempty"`
-	ShopperIP                        string          `json:"shopperIP,omitempty"`
-	ShopperLocale                    string          `json:"shopperLocale,omitempty"`
-	ShopperName                      *Name           `json:"shopperName,omitempty"`
-	SelectedRecurringDetailReference string          `json:"selectedRecurringDetailReference,omitempty"`
-	BrowserInfo                      *BrowserInfo    `json:"browserInfo,omitempty"` // Required for a 3DS process
-	CaptureDelayHours                *int            `json:"captureDelayHours,omitempty"`
+	AdditionalData                   *AdditionalData    `json:"additionalData,omitempty"`
+	Card                             *Card              `json:"card,omitempty"`
+	Amount                           *Amount            `json:"amount"`
+	BillingAddress                   *Address           `json:"billingAddress,omitempty"`
+	DeliveryAddress                  *Address           `json:"deliveryAddress,omitempty"`
+	Reference                        string             `json:"reference"`
+	MerchantAccount                  string             `json:"merchantAccount"`
+	ShopperReference                 string             `json:"shopperReference,omitempty"` // Mandatory for recurring payment
+	Recurring                        *Recurring         `json:"recurring,omitempty"`
+	ShopperEmail                     string             `json:"shopperEmail,omitempty"`
+	ShopperInteraction               ShopperInteraction `json:"shopperInteraction,omitempty"`
+	ShopperIP                        string             `json:"shopperIP,omitempty"`
+	ShopperLocale                    string             `json:"shopperLocale,omitempty"`
+	ShopperName                      *Name              `json:"shopperName,omitempty"`
+	SelectedRecurringDetailReference string             `json:"selectedRecurringDetailReference,omitempty"`
+	BrowserInfo                      *BrowserInfo       `json:"browserInfo,omitempty"` // Required for a 3DS process
+	CaptureDelayHours                *int               `json:"captureDelayHours,omitempty"`
 }
 
 // AuthoriseResponse is a response structure for Adyen
@@ -77,6 +85,8 @@ type AuthoriseResponse struct {
 }
 
 // AdditionalData stores encrypted information about customer's credit card
+//
+// Keys, that are not modelled as struct fields, are kept in Extra and sent back as is
 type AdditionalData struct {
 	Content                           string      `json:"card.encrypted.json,omitempty"`
 	AliasType                         string      `json:"aliasType,omitempty"`
@@ -97,6 +107,7 @@ type AdditionalData struct {
 	CVCResultRaw                      string      `json:"cvcResultRaw,omitempty"`
 	AVSResult                         AVSResponse `json:"avsResult,omitempty"`
 	AVSResultRaw                      string      `json:"avsResultRaw,omitempty"`
+	Extra                             ExtraData   `json:"-"`
 }
 
 // BrowserInfo hold information on the user browser
@@ -107,7 +118,7 @@ type BrowserInfo struct {
 
 // Recurring hold the behavior for a future payment : could be ONECLICK or RECURRING
 type Recurring struct {
-	Contract string `json:"contract"`
+	Contract ContractType `json:"contract"`
 }
 
 // FraudResult hold the fraud score of transaction
@@ -211,6 +222,8 @@ type issuer struct {
 // Link: https://docs.adyen.com/developers/ecommerce-integration/local-payment-methods
 //
 // Request description: https://docs.adyen.com/developers/api-reference/hosted-payment-pages-api#skipdetailsrequest
+//
+// New fields could be added with url tag, they are signed automatically by CalculateSignature
 type SkipHppRequest struct {
 	MerchantReference string `url:"merchantReference"`
 	PaymentAmount     int    `url:"paymentAmount"`
@@ -224,4 +237,15 @@ type SkipHppRequest struct {
 	CountryCode       string `url:"countryCode"`
 	BrandCode         string `url:"brandCode"`
 	IssuerID          string `url:"issuerId"`
+
+	// Optional fields are sent and signed only if specified
+	ShopperEmail       string       `url:"shopperEmail,omitempty"`
+	ShopperReference   string       `url:"shopperReference,omitempty"`
+	RecurringContract  ContractType `url:"recurringContract,omitempty"`
+	ResURL             string       `url:"resURL,omitempty"`
+	AllowedMethods     string       `url:"allowedMethods,omitempty"`
+	BlockedMethods     string       `url:"blockedMethods,omitempty"`
+	MerchantReturnData string       `url:"merchantReturnData,omitempty"`
+
+	OpenInvoiceData *OpenInvoiceData `url:"openinvoicedata,omitempty"`
 }
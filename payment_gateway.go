@@ -1,6 +1,10 @@
 package adyen
 
-import "github.com/google/go-querystring/query"
+import (
+	"context"
+
+	"github.com/google/go-querystring/query"
+)
 
 // PaymentGateway - Adyen payment transaction logic
 type PaymentGateway struct {
@@ -25,19 +29,30 @@ const authorise3DType = "authorise3d"
 //
 // Example:
 //   &adyen.AuthoriseEncrypted{
-//       Amount:           &adyen.Amount{Value: "2000", Currency: "EUR"},
+//       Amount:           &adyen.Amount{Value: 2000, Currency: "EUR"},
 //       MerchantAccount:  "merchant-account",
 //       AdditionalData:   &adyen.AdditionalData{Content: r.Form.Get("adyen-encrypted-data")}, // encrypted CC data
 //       ShopperReference: "unique-customer-reference",
-//       Recurring:        &adyen.Recurring{Contract:adyen.RecurringPaymentRecurring}
+//       Recurring:        &adyen.Recurring{Contract:adyen.ContractRecurring}
 //       Reference:        "some-merchant-reference",
 //   }
 //}
-// adyen.Recurring{Contract:adyen.RecurringPaymentRecurring} as one of the contracts
+// adyen.Recurring{Contract:adyen.ContractRecurring} as one of the contracts
+//
+// Recurring fields are validated before request is sent, see AuthoriseEncrypted.ValidateRecurring
 func (a *PaymentGateway) AuthoriseEncrypted(req *AuthoriseEncrypted) (*AuthoriseResponse, error) {
+	return a.AuthoriseEncryptedContext(context.Background(), req)
+}
+
+// AuthoriseEncryptedContext - Perform authorise payment in Adyen, bound to a given context
+func (a *PaymentGateway) AuthoriseEncryptedContext(ctx context.Context, req *AuthoriseEncrypted) (*AuthoriseResponse, error) {
+	if err := req.ValidateRecurring(); err != nil {
+		return nil, err
+	}
+
 	url := a.adyenURL(PaymentService, authoriseType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -54,9 +69,18 @@ func (a *PaymentGateway) AuthoriseEncrypted(req *AuthoriseEncrypted) (*Authorise
 //
 // Please use AuthoriseEncrypted instead and adyen frontend encryption library
 func (a *PaymentGateway) Authorise(req *Authorise) (*AuthoriseResponse, error) {
+	return a.AuthoriseContext(context.Background(), req)
+}
+
+// AuthoriseContext - Perform authorise payment in Adyen, bound to a given context
+func (a *PaymentGateway) AuthoriseContext(ctx context.Context, req *Authorise) (*AuthoriseResponse, error) {
+	if err := req.ValidateRecurring(); err != nil {
+		return nil, err
+	}
+
 	url := a.adyenURL(PaymentService, authoriseType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -69,7 +93,11 @@ func (a *PaymentGateway) Authorise(req *Authorise) (*AuthoriseResponse, error) {
 //
 // Link - https://docs.adyen.com/developers/api-reference/hosted-payment-pages-api
 func (a *PaymentGateway) DirectoryLookup(req *DirectoryLookupRequest) (*DirectoryLookupResponse, error) {
+	return a.DirectoryLookupContext(context.Background(), req)
+}
 
+// DirectoryLookupContext - Execute directory lookup request, bound to a given context
+func (a *PaymentGateway) DirectoryLookupContext(ctx context.Context, req *DirectoryLookupRequest) (*DirectoryLookupResponse, error) {
 	// Calculate HMAC signature to request
 	err := req.CalculateSignature(a.Adyen)
 	if err != nil {
@@ -81,7 +109,7 @@ func (a *PaymentGateway) DirectoryLookup(req *DirectoryLookupRequest) (*Director
 	v, _ := query.Values(req)
 	url = url + "?" + v.Encode()
 
-	resp, err := a.executeHpp(url, req)
+	resp, err := a.executeHpp(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -110,9 +138,14 @@ func (a *PaymentGateway) GetHPPRedirectURL(req *SkipHppRequest) (string, error)
 
 // Authorise3D - Perform authorise payment in Adyen
 func (a *PaymentGateway) Authorise3D(req *Authorise3D) (*AuthoriseResponse, error) {
+	return a.Authorise3DContext(context.Background(), req)
+}
+
+// Authorise3DContext - Perform authorise 3D payment in Adyen, bound to a given context
+func (a *PaymentGateway) Authorise3DContext(ctx context.Context, req *Authorise3D) (*AuthoriseResponse, error) {
 	url := a.adyenURL(PaymentService, authorise3DType, PaymentAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,514 @@
+package adyen
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PaymentState is a type definition for a state of a payment lifecycle
+type PaymentState string
+
+// Payment lifecycle states
+const (
+	PaymentStatePending           PaymentState = "Pending"
+	PaymentStateAuthorised        PaymentState = "Authorised"
+	PaymentStateRefused           PaymentState = "Refused"
+	PaymentStatePartiallyCaptured PaymentState = "PartiallyCaptured"
+	PaymentStateCaptured          PaymentState = "Captured"
+	PaymentStatePartiallyRefunded PaymentState = "PartiallyRefunded"
+	PaymentStateRefunded          PaymentState = "Refunded"
+	PaymentStateCancelled         PaymentState = "Cancelled"
+	PaymentStateChargeback        PaymentState = "Chargeback"
+)
+
+// TransitionError - event can't be applied to a payment in its current state
+type TransitionError struct {
+	PspReference string
+	State        PaymentState
+	Event        string
+}
+
+// Error - error interface implementation
+func (e *TransitionError) Error() string {
+	if e.State == "" {
+		return fmt.Sprintf("payment %s is not tracked, %s can't be applied", e.PspReference, e.Event)
+	}
+
+	return fmt.Sprintf("payment %s in state %s doesn't allow %s", e.PspReference, e.State, e.Event)
+}
+
+// PaymentLifecycle - state of a single payment
+//
+// Description:
+//
+//   - Authorised - authorised amount, all other amounts are in minor units of the same currency
+//   - Captured, Refunded, ChargedBack - confirmed totals
+//   - PendingCapture, PendingRefund - requested, but not yet confirmed by notification
+//   - CancelRequested - cancellation is requested, but not yet confirmed by notification
+type PaymentLifecycle struct {
+	PspReference      string
+	MerchantReference string
+	State             PaymentState
+	Authorised        Amount
+	Captured          int64
+	Refunded          int64
+	ChargedBack       int64
+	PendingCapture    int64
+	PendingRefund     int64
+	CancelRequested   bool
+	UpdatedAt         time.Time
+}
+
+// CapturableAmount - amount, that could be captured
+//
+// Refunds of already captured amount don't affect it, authorisation could be captured in multiple parts
+func (p PaymentLifecycle) CapturableAmount() int64 {
+	if !p.capturable() {
+		return 0
+	}
+
+	return nonNegative(p.Authorised.Value - p.Captured - p.PendingCapture)
+}
+
+// RefundableAmount - amount, that could be refunded
+func (p PaymentLifecycle) RefundableAmount() int64 {
+	switch p.State {
+	case PaymentStatePartiallyCaptured, PaymentStateCaptured, PaymentStatePartiallyRefunded:
+		return nonNegative(p.Captured - p.Refunded - p.PendingRefund)
+	}
+
+	return 0
+}
+
+// CanCapture - checks if payment could be captured
+func (p PaymentLifecycle) CanCapture() bool {
+	return !p.CancelRequested && p.CapturableAmount() > 0
+}
+
+// CanRefund - checks if payment could be refunded
+func (p PaymentLifecycle) CanRefund() bool {
+	return p.RefundableAmount() > 0
+}
+
+// CanCancel - checks if authorisation could be cancelled
+func (p PaymentLifecycle) CanCancel() bool {
+	return p.State == PaymentStateAuthorised && p.Captured == 0 && p.PendingCapture == 0 && !p.CancelRequested
+}
+
+// capturable - checks if authorisation is open and not fully captured yet
+func (p PaymentLifecycle) capturable() bool {
+	switch p.State {
+	case PaymentStateAuthorised, PaymentStatePartiallyCaptured, PaymentStatePartiallyRefunded, PaymentStateRefunded:
+		return p.Captured < p.Authorised.Value
+	}
+
+	return false
+}
+
+// settle - recalculates state from confirmed totals
+//
+// Refund states take precedence over capture ones, not captured part of authorisation
+// is still capturable in PartiallyRefunded and Refunded states, see CapturableAmount
+func (p *PaymentLifecycle) settle() {
+	switch {
+	case p.ChargedBack > 0:
+		p.State = PaymentStateChargeback
+	case p.Refunded > 0 && p.Refunded >= p.Captured:
+		p.State = PaymentStateRefunded
+	case p.Refunded > 0:
+		p.State = PaymentStatePartiallyRefunded
+	case p.Captured > 0 && p.Captured >= p.Authorised.Value:
+		p.State = PaymentStateCaptured
+	case p.Captured > 0:
+		p.State = PaymentStatePartiallyCaptured
+	default:
+		p.State = PaymentStateAuthorised
+	}
+}
+
+// captureImplied - treats authorised amount as captured for payments with automatic capture,
+// Adyen doesn't send CAPTURE notifications for them by default
+func (p *PaymentLifecycle) captureImplied() {
+	if p.State == PaymentStateAuthorised && p.Captured == 0 {
+		p.Captured = p.Authorised.Value
+		p.settle()
+	}
+}
+
+// PaymentTracker - folds API results and notifications into per-pspReference payment lifecycle
+//
+// Every Apply... method returns *TransitionError if event is not allowed in the current payment state.
+// Notifications, that don't affect payment state, f.e. REPORT_AVAILABLE, are ignored.
+// Repeated deliveries of the same modification notification, see NotificationKey, are applied once.
+//
+// Tracker keeps state in memory and is safe for concurrent use
+type PaymentTracker struct {
+	mu       sync.Mutex
+	payments map[string]*PaymentLifecycle
+	applied  map[string]map[NotificationKey]struct{} // applied modifications per payment pspReference
+}
+
+// NewPaymentTracker - creates PaymentTracker instance
+func NewPaymentTracker() *PaymentTracker {
+	return &PaymentTracker{
+		payments: make(map[string]*PaymentLifecycle),
+		applied:  make(map[string]map[NotificationKey]struct{}),
+	}
+}
+
+// Get - returns a copy of payment lifecycle
+func (t *PaymentTracker) Get(pspReference string) (PaymentLifecycle, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.payments[pspReference]
+	if !ok {
+		return PaymentLifecycle{}, false
+	}
+
+	return *p, true
+}
+
+// ApplyAuthorise - applies result of Payment API authorise call
+func (t *PaymentTracker) ApplyAuthorise(res *AuthoriseResponse, amount Amount) error {
+	return t.applyResult(res.PspReference, "", res.ResultCode, amount)
+}
+
+// ApplyPayments - applies result of Checkout API payments or payments/details call
+func (t *PaymentTracker) ApplyPayments(pspReference, merchantReference, resultCode string, amount Amount) error {
+	return t.applyResult(pspReference, merchantReference, resultCode, amount)
+}
+
+// ApplyCapture - applies accepted Payment API capture request
+func (t *PaymentTracker) ApplyCapture(req *Capture, res *CaptureResponse) error {
+	return t.requestCapture(req.OriginalReference, modificationValue(req.ModificationAmount))
+}
+
+// ApplyPaymentCapture - applies accepted Checkout API capture request
+func (t *PaymentTracker) ApplyPaymentCapture(res *PaymentCaptureResource) error {
+	return t.requestCapture(res.PaymentPspReference, modificationValue(res.Amount))
+}
+
+// ApplyRefund - applies accepted Payment API refund request
+func (t *PaymentTracker) ApplyRefund(req *Refund, res *RefundResponse) error {
+	return t.requestRefund(req.OriginalReference, modificationValue(req.ModificationAmount))
+}
+
+// ApplyPaymentRefund - applies accepted Checkout API refund request
+func (t *PaymentTracker) ApplyPaymentRefund(res *PaymentRefundResource) error {
+	return t.requestRefund(res.PaymentPspReference, modificationValue(res.Amount))
+}
+
+// ApplyCancel - applies accepted Payment API cancel request
+func (t *PaymentTracker) ApplyCancel(req *Cancel, res *CancelResponse) error {
+	return t.requestCancel(req.OriginalReference)
+}
+
+// ApplyPaymentCancel - applies accepted Checkout API cancel request
+func (t *PaymentTracker) ApplyPaymentCancel(res *PaymentCancelResource) error {
+	return t.requestCancel(res.PaymentPspReference)
+}
+
+// ApplyNotification - applies notification item
+//
+// Modification notifications are applied to the payment referenced by originalReference
+func (t *PaymentTracker) ApplyNotification(item NotificationRequestItemData) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if item.EventCode == EventCodeAuthorisation {
+		return t.applyAuthorisation(item)
+	}
+
+	psp := item.OriginalReference
+	if psp == "" {
+		psp = item.PspReference
+	}
+
+	p, ok := t.payments[psp]
+	if !ok {
+		if item.EventCode.IsModification() || item.EventCode.IsChargeback() {
+			return &TransitionError{PspReference: psp, Event: string(item.EventCode)}
+		}
+		return nil
+	}
+
+	key := item.Key()
+	if _, ok := t.applied[psp][key]; ok {
+		// repeated delivery of already applied notification
+		return nil
+	}
+
+	if err := applyModification(p, item); err != nil {
+		return err
+	}
+	p.UpdatedAt = item.EventDate
+
+	if t.applied[psp] == nil {
+		t.applied[psp] = make(map[NotificationKey]struct{})
+	}
+	t.applied[psp][key] = struct{}{}
+
+	return nil
+}
+
+// applyAuthorisation - applies AUTHORISATION notification, tracking the payment if needed
+func (t *PaymentTracker) applyAuthorisation(item NotificationRequestItemData) error {
+	p := t.payment(item.PspReference)
+	success := bool(item.Success)
+	if item.MerchantReference != "" {
+		p.MerchantReference = item.MerchantReference
+	}
+
+	switch {
+	case p.State == "" || p.State == PaymentStatePending:
+		p.Authorised = item.Amount
+		p.State = PaymentStateRefused
+		if success {
+			p.State = PaymentStateAuthorised
+		}
+	case success && p.State == PaymentStateRefused,
+		!success && p.State != PaymentStateRefused && p.State != PaymentStateCancelled:
+		// repeated notification contradicts current state
+		return &TransitionError{PspReference: p.PspReference, State: p.State, Event: string(item.EventCode)}
+	}
+	p.UpdatedAt = item.EventDate
+
+	return nil
+}
+
+// applyModification - applies modification or dispute notification
+func applyModification(p *PaymentLifecycle, item NotificationRequestItemData) error {
+	amount := item.Amount.Value
+	illegal := &TransitionError{PspReference: p.PspReference, State: p.State, Event: string(item.EventCode)}
+
+	if !item.Success {
+		// modification is rejected, the payment is unchanged
+		switch item.EventCode {
+		case EventCodeCapture:
+			p.PendingCapture = nonNegative(p.PendingCapture - amount)
+		case EventCodeRefund:
+			p.PendingRefund = nonNegative(p.PendingRefund - amount)
+		case EventCodeCancellation, EventCodeCancelOrRefund, EventCodeTechnicalCancel:
+			p.CancelRequested = false
+		}
+		return nil
+	}
+
+	switch item.EventCode {
+	case EventCodeAuthorisationAdjustment:
+		if p.State != PaymentStateAuthorised && p.State != PaymentStatePartiallyCaptured {
+			return illegal
+		}
+		p.Authorised.Value = amount
+		p.settle()
+
+	case EventCodeCapture:
+		if !p.capturable() {
+			return illegal
+		}
+		p.Captured += amount
+		p.PendingCapture = nonNegative(p.PendingCapture - amount)
+		p.settle()
+
+	case EventCodeCaptureFailed:
+		if p.Captured == 0 {
+			return illegal
+		}
+		p.Captured = nonNegative(p.Captured - amount)
+		p.settle()
+
+	case EventCodeRefund:
+		p.captureImplied()
+		if p.RefundableAmount() == 0 && p.PendingRefund == 0 {
+			return illegal
+		}
+		p.PendingRefund = nonNegative(p.PendingRefund - amount)
+		// refunded total never exceeds captured one
+		if amount > p.Captured-p.Refunded {
+			amount = p.Captured - p.Refunded
+		}
+		p.Refunded += amount
+		p.settle()
+
+	case EventCodeRefundFailed, EventCodeRefundedReversed:
+		if p.Refunded == 0 {
+			return illegal
+		}
+		p.Refunded = nonNegative(p.Refunded - amount)
+		p.settle()
+
+	case EventCodeCancellation, EventCodeTechnicalCancel:
+		if p.State != PaymentStateAuthorised || p.Captured > 0 {
+			return illegal
+		}
+		p.State = PaymentStateCancelled
+		p.CancelRequested = false
+
+	case EventCodeCancelOrRefund:
+		switch {
+		case p.State == PaymentStateAuthorised && p.Captured == 0:
+			p.State = PaymentStateCancelled
+		case p.Captured > 0 && p.State != PaymentStateChargeback:
+			// not captured part of authorisation is released
+			p.Authorised.Value = p.Captured
+			p.Refunded = p.Captured
+			p.PendingRefund = 0
+			p.PendingCapture = 0
+			p.settle()
+		default:
+			return illegal
+		}
+		p.CancelRequested = false
+
+	case EventCodeChargeback, EventCodeSecondChargeback, EventCodePrearbitrationLost:
+		p.captureImplied()
+		if p.Captured == 0 {
+			return illegal
+		}
+		if item.EventCode == EventCodeChargeback || p.ChargedBack == 0 {
+			p.ChargedBack += amount
+		}
+		p.settle()
+
+	case EventCodeChargebackReversed, EventCodePrearbitrationWon:
+		if p.ChargedBack == 0 {
+			return illegal
+		}
+		p.ChargedBack = nonNegative(p.ChargedBack - amount)
+		p.settle()
+	}
+
+	return nil
+}
+
+// applyResult - applies authorisation result code of an API call
+func (t *PaymentTracker) applyResult(pspReference, merchantReference, resultCode string, amount Amount) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.payment(pspReference)
+	if merchantReference != "" {
+		p.MerchantReference = merchantReference
+	}
+
+	var state PaymentState
+	switch resultCode {
+	case ResultCodeAuthorised:
+		state = PaymentStateAuthorised
+	case ResultCodeRefused, ResultCodeError:
+		state = PaymentStateRefused
+	case ResultCodeCancelled:
+		state = PaymentStateCancelled
+	default:
+		state = PaymentStatePending
+	}
+
+	if p.State != "" && p.State != PaymentStatePending {
+		// notifications could be applied before the API result, current state is kept
+		if state == PaymentStatePending || state == p.State || state == PaymentStateAuthorised && p.State != PaymentStateRefused {
+			return nil
+		}
+		return &TransitionError{PspReference: pspReference, State: p.State, Event: resultCode}
+	}
+
+	p.State = state
+	p.Authorised = amount
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// requestCapture - reserves amount of requested capture
+func (t *PaymentTracker) requestCapture(pspReference string, amount int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.payments[pspReference]
+	if !ok || !p.CanCapture() || amount > p.CapturableAmount() {
+		return t.transitionError(pspReference, "capture")
+	}
+
+	if amount == 0 {
+		amount = p.CapturableAmount()
+	}
+	p.PendingCapture += amount
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// requestRefund - reserves amount of requested refund
+func (t *PaymentTracker) requestRefund(pspReference string, amount int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.payments[pspReference]
+	if !ok || !p.CanRefund() || amount > p.RefundableAmount() {
+		return t.transitionError(pspReference, "refund")
+	}
+
+	if amount == 0 {
+		amount = p.RefundableAmount()
+	}
+	p.PendingRefund += amount
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// requestCancel - marks payment as being cancelled
+func (t *PaymentTracker) requestCancel(pspReference string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.payments[pspReference]
+	if !ok || !p.CanCancel() {
+		return t.transitionError(pspReference, "cancel")
+	}
+
+	p.CancelRequested = true
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// payment - returns tracked payment, new one is created if needed
+func (t *PaymentTracker) payment(pspReference string) *PaymentLifecycle {
+	p, ok := t.payments[pspReference]
+	if !ok {
+		p = &PaymentLifecycle{PspReference: pspReference}
+		t.payments[pspReference] = p
+	}
+
+	return p
+}
+
+// transitionError - returns TransitionError for a given payment
+func (t *PaymentTracker) transitionError(pspReference, event string) error {
+	e := &TransitionError{PspReference: pspReference, Event: event}
+	if p, ok := t.payments[pspReference]; ok {
+		e.State = p.State
+	}
+
+	return e
+}
+
+// modificationValue - returns value of an optional modification amount
+func modificationValue(a *Amount) int64 {
+	if a == nil {
+		return 0
+	}
+
+	return a.Value
+}
+
+// nonNegative - returns zero for negative values
+func nonNegative(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+
+	return v
+}
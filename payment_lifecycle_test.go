@@ -0,0 +1,309 @@
+package adyen
+
+import (
+	"testing"
+)
+
+func testNotificationItem(eventCode EventCode, psp, original string, value int64, success bool) NotificationRequestItemData {
+	return NotificationRequestItemData{
+		EventCode:         eventCode,
+		PspReference:      psp,
+		OriginalReference: original,
+		Amount:            Amount{Value: value, Currency: "EUR"},
+		Success:           StringBool(success),
+	}
+}
+
+func TestPaymentTrackerCaptureAndRefund(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewPaymentTracker()
+	eur := func(v int64) *Amount { return &Amount{Value: v, Currency: "EUR"} }
+
+	err := tracker.ApplyAuthorise(&AuthoriseResponse{PspReference: "P1", ResultCode: ResultCodeAuthorised}, *eur(1000))
+	equals(t, nil, err)
+
+	p, ok := tracker.Get("P1")
+	assert(t, ok, "expected payment to be tracked")
+	equals(t, PaymentStateAuthorised, p.State)
+	assert(t, p.CanCapture() && p.CanCancel() && !p.CanRefund(), "expected authorised payment to be capturable and cancellable")
+
+	// capture more than authorised is rejected
+	_, isTransition := tracker.ApplyCapture(&Capture{OriginalReference: "P1", ModificationAmount: eur(1500)}, &CaptureResponse{}).(*TransitionError)
+	assert(t, isTransition, "expected capture over authorised amount to be rejected")
+
+	equals(t, nil, tracker.ApplyCapture(&Capture{OriginalReference: "P1", ModificationAmount: eur(400)}, &CaptureResponse{PspReference: "C1"}))
+	p, _ = tracker.Get("P1")
+	equals(t, int64(400), p.PendingCapture)
+	equals(t, int64(600), p.CapturableAmount())
+	assert(t, !p.CanCancel(), "expected payment with pending capture not to be cancellable")
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeCapture, "C1", "P1", 400, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStatePartiallyCaptured, p.State)
+	equals(t, int64(400), p.Captured)
+	equals(t, int64(0), p.PendingCapture)
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeCapture, "C2", "P1", 600, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateCaptured, p.State)
+	assert(t, !p.CanCapture() && p.CanRefund(), "expected captured payment to be refundable only")
+
+	equals(t, nil, tracker.ApplyPaymentRefund(&PaymentRefundResource{PaymentPspReference: "P1", Amount: eur(300)}))
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeRefund, "R1", "P1", 300, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStatePartiallyRefunded, p.State)
+	equals(t, int64(700), p.RefundableAmount())
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeRefund, "R2", "P1", 700, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateRefunded, p.State)
+	assert(t, !p.CanRefund(), "expected refunded payment not to be refundable")
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeRefundFailed, "R2", "P1", 700, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStatePartiallyRefunded, p.State)
+}
+
+func TestPaymentTrackerTransitions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		events []NotificationRequestItemData
+		state  PaymentState
+		err    bool
+	}{
+		{
+			name: "refused",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, false),
+			},
+			state: PaymentStateRefused,
+		},
+		{
+			name: "capture of refused payment",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, false),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, true),
+			},
+			state: PaymentStateRefused,
+			err:   true,
+		},
+		{
+			name: "cancellation",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCancellation, "X1", "P1", 1000, true),
+			},
+			state: PaymentStateCancelled,
+		},
+		{
+			name: "capture of cancelled payment",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCancellation, "X1", "P1", 1000, true),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, true),
+			},
+			state: PaymentStateCancelled,
+			err:   true,
+		},
+		{
+			name: "failed capture keeps payment authorised",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, false),
+			},
+			state: PaymentStateAuthorised,
+		},
+		{
+			name: "cancel or refund of captured payment",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, true),
+				testNotificationItem(EventCodeCancelOrRefund, "X1", "P1", 1000, true),
+			},
+			state: PaymentStateRefunded,
+		},
+		{
+			name: "refund of automatically captured payment",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeRefund, "R1", "P1", 1000, true),
+			},
+			state: PaymentStateRefunded,
+		},
+		{
+			name: "chargeback and reversal",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, true),
+				testNotificationItem(EventCodeChargeback, "D1", "P1", 1000, true),
+				testNotificationItem(EventCodeChargebackReversed, "D1", "P1", 1000, true),
+			},
+			state: PaymentStateCaptured,
+		},
+		{
+			name: "duplicate authorisation",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+				testNotificationItem(EventCodeCapture, "C1", "P1", 1000, true),
+				testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+			},
+			state: PaymentStateCaptured,
+		},
+		{
+			name: "modification of unknown payment",
+			events: []NotificationRequestItemData{
+				testNotificationItem(EventCodeRefund, "R1", "P1", 1000, true),
+			},
+			err: true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			tracker := NewPaymentTracker()
+
+			var err error
+			for _, e := range c.events {
+				if err = tracker.ApplyNotification(e); err != nil {
+					break
+				}
+			}
+
+			if c.err {
+				_, ok := err.(*TransitionError)
+				assert(t, ok, "expected transition error, got: "+errString(err))
+			} else {
+				equals(t, nil, err)
+			}
+
+			p, _ := tracker.Get("P1")
+			equals(t, c.state, p.State)
+		})
+	}
+}
+
+func TestPaymentTrackerDuplicateDelivery(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewPaymentTracker()
+
+	events := []NotificationRequestItemData{
+		testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true),
+		testNotificationItem(EventCodeCapture, "C1", "P1", 400, true),
+		testNotificationItem(EventCodeCapture, "C1", "P1", 400, true),
+		testNotificationItem(EventCodeRefund, "R1", "P1", 100, true),
+		testNotificationItem(EventCodeRefund, "R1", "P1", 100, true),
+	}
+	for _, e := range events {
+		equals(t, nil, tracker.ApplyNotification(e))
+	}
+
+	p, _ := tracker.Get("P1")
+	equals(t, PaymentStatePartiallyRefunded, p.State)
+	equals(t, int64(400), p.Captured)
+	equals(t, int64(100), p.Refunded)
+
+	// refund over captured amount is capped
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeRefund, "R2", "P1", 500, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateRefunded, p.State)
+	equals(t, int64(400), p.Refunded)
+}
+
+func TestPaymentTrackerCaptureAfterRefund(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewPaymentTracker()
+
+	events := []NotificationRequestItemData{
+		testNotificationItem(EventCodeAuthorisation, "P1", "", 100, true),
+		testNotificationItem(EventCodeCapture, "C1", "P1", 50, true),
+		testNotificationItem(EventCodeRefund, "R1", "P1", 20, true),
+	}
+	for _, e := range events {
+		equals(t, nil, tracker.ApplyNotification(e))
+	}
+
+	p, _ := tracker.Get("P1")
+	equals(t, PaymentStatePartiallyRefunded, p.State)
+	equals(t, int64(50), p.CapturableAmount())
+	assert(t, p.CanCapture() && p.CanRefund(), "expected partially refunded payment to be capturable and refundable")
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeCapture, "C2", "P1", 50, true)))
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStatePartiallyRefunded, p.State)
+	equals(t, int64(100), p.Captured)
+	equals(t, int64(0), p.CapturableAmount())
+	equals(t, int64(80), p.RefundableAmount())
+
+	// refund of the whole captured part keeps the rest of authorisation capturable
+	tracker = NewPaymentTracker()
+	events = []NotificationRequestItemData{
+		testNotificationItem(EventCodeAuthorisation, "P1", "", 100, true),
+		testNotificationItem(EventCodeCapture, "C1", "P1", 50, true),
+		testNotificationItem(EventCodeRefund, "R1", "P1", 50, true),
+	}
+	for _, e := range events {
+		equals(t, nil, tracker.ApplyNotification(e))
+	}
+
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateRefunded, p.State)
+	equals(t, int64(50), p.CapturableAmount())
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeCapture, "C2", "P1", 50, true)))
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeRefund, "R2", "P1", 50, true)))
+
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateRefunded, p.State)
+	assert(t, !p.CanCapture() && !p.CanRefund(), "expected refunded payment not to be capturable or refundable")
+
+	// cancel or refund releases not captured part of authorisation
+	tracker = NewPaymentTracker()
+	events = []NotificationRequestItemData{
+		testNotificationItem(EventCodeAuthorisation, "P1", "", 100, true),
+		testNotificationItem(EventCodeCapture, "C1", "P1", 50, true),
+		testNotificationItem(EventCodeCancelOrRefund, "X1", "P1", 100, true),
+	}
+	for _, e := range events {
+		equals(t, nil, tracker.ApplyNotification(e))
+	}
+
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateRefunded, p.State)
+	assert(t, !p.CanCapture(), "expected cancelled or refunded payment not to be capturable")
+}
+
+func TestPaymentTrackerResultBeforeNotification(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewPaymentTracker()
+	amount := Amount{Value: 1000, Currency: "EUR"}
+
+	equals(t, nil, tracker.ApplyPayments("P1", "order-1", ResultCodeRedirectShopper, amount))
+	p, _ := tracker.Get("P1")
+	equals(t, PaymentStatePending, p.State)
+
+	equals(t, nil, tracker.ApplyNotification(testNotificationItem(EventCodeAuthorisation, "P1", "", 1000, true)))
+	// late API result doesn't override state from notification
+	equals(t, nil, tracker.ApplyPayments("P1", "order-1", ResultCodePending, amount))
+
+	p, _ = tracker.Get("P1")
+	equals(t, PaymentStateAuthorised, p.State)
+	equals(t, "order-1", p.MerchantReference)
+
+	err := tracker.ApplyPayments("P1", "order-1", ResultCodeRefused, amount)
+	_, ok := err.(*TransitionError)
+	assert(t, ok, "expected refusal of authorised payment to be rejected")
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
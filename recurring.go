@@ -28,22 +28,56 @@ type RecurringDetailsResult struct {
 
 // RecurringDetail structure to hold information associated to a recurring payment
 //
+// Depending on a payment method, stored details are in Card, Bank (SEPA Direct Debit), Elv or TokenDetails
+// (f.e. PayPal or network tokens). Keys without a struct field are kept in AdditionalData.Extra
+//
 // Link - https://docs.adyen.com/developers/api-reference/recurring-api#recurringdetail
 type RecurringDetail struct {
-	Acquirer        string `json:"acquirer"`
-	AcquirerAccount string `json:"acquirerAccount"`
-	AdditionalData  struct {
-		CardBin string `json:"cardBin"`
-	} `json:"additionalData"`
-	Alias                    string   `json:"alias"`
-	AliasType                string   `json:"aliasType"`
-	Card                     Card     `json:"card,omitempty"`
-	ContractTypes            []string `json:"contractTypes"`
-	CreationDate             string   `json:"creationDate"`
-	FirstPspReference        string   `json:"firstPspReference"`
-	PaymentMethodVariant     string   `json:"paymentMethodVariant"`
-	RecurringDetailReference string   `json:"recurringDetailReference"`
-	Variant                  string   `json:"variant"`
+	Acquirer                 string                        `json:"acquirer"`
+	AcquirerAccount          string                        `json:"acquirerAccount"`
+	AdditionalData           RecurringDetailAdditionalData `json:"additionalData"`
+	Alias                    string                        `json:"alias"`
+	AliasType                string                        `json:"aliasType"`
+	Bank                     *BankAccount                  `json:"bank,omitempty"`
+	BillingAddress           *Address                      `json:"billingAddress,omitempty"`
+	Card                     Card                          `json:"card,omitempty"`
+	ContractTypes            []string                      `json:"contractTypes"`
+	CreationDate             string                        `json:"creationDate"`
+	Elv                      *ELV                          `json:"elv,omitempty"`
+	FirstPspReference        string                        `json:"firstPspReference"`
+	Name                     string                        `json:"name,omitempty"`
+	NetworkTxReference       string                        `json:"networkTxReference,omitempty"`
+	PaymentMethodVariant     string                        `json:"paymentMethodVariant"`
+	RecurringDetailReference string                        `json:"recurringDetailReference"`
+	ShopperName              *Name                         `json:"shopperName,omitempty"`
+	SocialSecurityNumber     string                        `json:"socialSecurityNumber,omitempty"`
+	TokenDetails             *TokenDetails                 `json:"tokenDetails,omitempty"`
+	Variant                  string                        `json:"variant"`
+}
+
+// RecurringDetailAdditionalData structure to hold additional data of a recurring detail
+type RecurringDetailAdditionalData struct {
+	CardBin string    `json:"cardBin,omitempty"`
+	Extra   ExtraData `json:"-"`
+}
+
+// ELV structure to hold details of a stored German direct debit (Elektronisches Lastschriftverfahren) account
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/listRecurringDetails__resParam_details-RecurringDetail-elv
+type ELV struct {
+	AccountHolderName string `json:"accountHolderName"`
+	BankAccountNumber string `json:"bankAccountNumber"`
+	BankLocation      string `json:"bankLocation,omitempty"`
+	BankLocationID    string `json:"bankLocationId"`
+	BankName          string `json:"bankName,omitempty"`
+}
+
+// TokenDetails structure to hold details of a stored token, f.e. PayPal account or network token
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/listRecurringDetails__resParam_details-RecurringDetail-tokenDetails
+type TokenDetails struct {
+	TokenData     map[string]string `json:"tokenData,omitempty"`
+	TokenDataType string            `json:"tokenDataType,omitempty"`
 }
 
 // RecurringDisableRequest structure to hold information regarding disable recurring request
@@ -56,7 +90,7 @@ type RecurringDisableRequest struct {
 	MerchantAccount  string `json:"merchantAccount"`
 	ShopperReference string `json:"shopperReference"`
 	// Type of a contract ONECLICK, RECURRING, PAYOUT or combination of them
-	Contract string `json:"contract,omitempty"`
+	Contract ContractType `json:"contract,omitempty"`
 	// ID of a customer saved payment method, all will be disabled if none is specified
 	RecurringDetailReference string `json:"recurringDetailReference,omitempty"`
 }
@@ -67,3 +101,166 @@ type RecurringDisableRequest struct {
 type RecurringDisableResponse struct {
 	Response string `json:"response"`
 }
+
+/**************
+* Store token *
+**************/
+
+// StoreTokenResultSuccess - result of successful StoreToken request
+const StoreTokenResultSuccess = "Success"
+
+// StoreTokenRequest structure to tokenize card or bank account details without a payment
+//
+// Either Card, BankAccount or encrypted card data in AdditionalData has to be specified.
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/storeToken
+type StoreTokenRequest struct {
+	MerchantAccount      string          `json:"merchantAccount"`
+	ShopperReference     string          `json:"shopperReference"`
+	ShopperEmail         string          `json:"shopperEmail"`
+	Recurring            *Recurring      `json:"recurring"`
+	AdditionalData       *AdditionalData `json:"additionalData,omitempty"`
+	Card                 *Card           `json:"card,omitempty"`
+	BankAccount          *BankAccount    `json:"bank,omitempty"`
+	SelectedBrand        string          `json:"selectedBrand,omitempty"`
+	ShopperIP            string          `json:"shopperIP,omitempty"`
+	ShopperName          *Name           `json:"shopperName,omitempty"`
+	DateOfBirth          string          `json:"dateOfBirth,omitempty"`
+	SocialSecurityNumber string          `json:"socialSecurityNumber,omitempty"`
+}
+
+// StoreTokenResult structure to hold response for store token request
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/storeToken__resParam
+type StoreTokenResult struct {
+	PspReference             string            `json:"pspReference"`
+	RecurringDetailReference string            `json:"recurringDetailReference"`
+	Result                   string            `json:"result"`
+	AdditionalData           map[string]string `json:"additionalData,omitempty"`
+}
+
+/*****************
+* Notify shopper *
+*****************/
+
+// NotifyShopperRequest structure to send pre-debit notification to a shopper, f.e. for Indian mandates
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/notifyShopper
+type NotifyShopperRequest struct {
+	MerchantAccount          string  `json:"merchantAccount"`
+	ShopperReference         string  `json:"shopperReference"`
+	Reference                string  `json:"reference"`
+	Amount                   *Amount `json:"amount"`
+	RecurringDetailReference string  `json:"recurringDetailReference,omitempty"`
+	StoredPaymentMethodID    string  `json:"storedPaymentMethodId,omitempty"`
+	BillingDate              string  `json:"billingDate,omitempty"` // YYYY-MM-DD
+	BillingSequenceNumber    string  `json:"billingSequenceNumber,omitempty"`
+	DisplayedReference       string  `json:"displayedReference,omitempty"`
+}
+
+// NotifyShopperResult structure to hold response for notify shopper request
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/notifyShopper__resParam
+type NotifyShopperResult struct {
+	PspReference                 string `json:"pspReference"`
+	Reference                    string `json:"reference"`
+	ResultCode                   string `json:"resultCode"`
+	Message                      string `json:"message,omitempty"`
+	DisplayedReference           string `json:"displayedReference,omitempty"`
+	ShopperNotificationReference string `json:"shopperNotificationReference,omitempty"`
+	StoredPaymentMethodID        string `json:"storedPaymentMethodId,omitempty"`
+}
+
+/***************************
+* Schedule account updater *
+***************************/
+
+// ScheduleAccountUpdaterRequest structure to schedule update of stored card details
+//
+// Either Card or SelectedRecurringDetailReference with ShopperReference has to be specified.
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/scheduleAccountUpdater
+type ScheduleAccountUpdaterRequest struct {
+	MerchantAccount                  string            `json:"merchantAccount"`
+	Reference                        string            `json:"reference"`
+	Card                             *Card             `json:"card,omitempty"`
+	SelectedRecurringDetailReference string            `json:"selectedRecurringDetailReference,omitempty"`
+	ShopperReference                 string            `json:"shopperReference,omitempty"`
+	AdditionalData                   map[string]string `json:"additionalData,omitempty"`
+}
+
+// ScheduleAccountUpdaterResult structure to hold response for schedule account updater request
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/scheduleAccountUpdater__resParam
+type ScheduleAccountUpdaterResult struct {
+	PspReference string `json:"pspReference"`
+	Result       string `json:"result"`
+}
+
+/**********
+* Permits *
+**********/
+
+// PermitRestriction structure to limit payments, made with a permit
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/createPermit__reqParam_permits-restriction
+type PermitRestriction struct {
+	MaxAmount              *Amount `json:"maxAmount,omitempty"`
+	SingleTransactionLimit *Amount `json:"singleTransactionLimit,omitempty"`
+	SingleUse              bool    `json:"singleUse,omitempty"`
+}
+
+// Permit structure to allow a partner to make payments with shopper's stored payment details
+//
+// ValidTillDate is in ISO 8601 format, f.e. 2021-12-31T23:59:59+01:00
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/createPermit__reqParam_permits
+type Permit struct {
+	PartnerID        string             `json:"partnerId,omitempty"`
+	ProfileReference string             `json:"profileReference,omitempty"`
+	Restriction      *PermitRestriction `json:"restriction,omitempty"`
+	ResultKey        string             `json:"resultKey,omitempty"`
+	ValidTillDate    string             `json:"validTillDate,omitempty"`
+}
+
+// CreatePermitRequest structure to create permits for a stored payment details
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/createPermit
+type CreatePermitRequest struct {
+	MerchantAccount          string   `json:"merchantAccount"`
+	ShopperReference         string   `json:"shopperReference"`
+	RecurringDetailReference string   `json:"recurringDetailReference"`
+	Permits                  []Permit `json:"permits"`
+}
+
+// PermitResult structure to hold a token of a created permit
+//
+// ResultKey matches ResultKey of a requested Permit
+type PermitResult struct {
+	ResultKey string `json:"resultKey"`
+	Token     string `json:"token"`
+}
+
+// CreatePermitResult structure to hold response for create permit request
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/createPermit__resParam
+type CreatePermitResult struct {
+	PspReference     string         `json:"pspReference"`
+	PermitResultList []PermitResult `json:"permitResultList"`
+}
+
+// DisablePermitRequest structure to disable a permit
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/disablePermit
+type DisablePermitRequest struct {
+	MerchantAccount string `json:"merchantAccount"`
+	Token           string `json:"token"`
+}
+
+// DisablePermitResult structure to hold response for disable permit request
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/disablePermit__resParam
+type DisablePermitResult struct {
+	PspReference string `json:"pspReference"`
+	Status       string `json:"status"`
+}
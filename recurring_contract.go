@@ -0,0 +1,255 @@
+package adyen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContractType is a type definition for recurring contracts, payment details are stored for
+//
+// Several contracts could be combined with Contracts, f.e. ContractOneClickRecurring
+//
+// Link - https://docs.adyen.com/online-payments/tokenization/create-and-use-tokens#recurring-contract-types
+type ContractType string
+
+// Recurring contracts
+const (
+	ContractOneClick          ContractType = "ONECLICK"
+	ContractRecurring         ContractType = "RECURRING"
+	ContractPayout            ContractType = "PAYOUT"
+	ContractOneClickRecurring ContractType = "ONECLICK,RECURRING"
+)
+
+// contractSeparator - separator of combined contracts
+const contractSeparator = ","
+
+// Contracts - combines several contracts into one, f.e. Contracts(ContractRecurring, ContractPayout)
+func Contracts(contracts ...ContractType) ContractType {
+	parts := make([]string, 0, len(contracts))
+	for _, c := range contracts {
+		if c != "" {
+			parts = append(parts, string(c))
+		}
+	}
+
+	return ContractType(strings.Join(parts, contractSeparator))
+}
+
+// Has - checks if contract is, or is combined with a given single contract
+func (c ContractType) Has(contract ContractType) bool {
+	for _, part := range strings.Split(string(c), contractSeparator) {
+		if ContractType(part) == contract {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate - checks that contract is a known contract or a combination of known contracts
+func (c ContractType) Validate() error {
+	if c == "" {
+		return errors.New("recurring contract is not specified")
+	}
+
+	seen := make(map[ContractType]bool)
+	for _, part := range strings.Split(string(c), contractSeparator) {
+		contract := ContractType(part)
+
+		switch contract {
+		case ContractOneClick, ContractRecurring, ContractPayout:
+		default:
+			return fmt.Errorf("unknown recurring contract %q", part)
+		}
+
+		if seen[contract] {
+			return fmt.Errorf("recurring contract %q is specified more than once", part)
+		}
+		seen[contract] = true
+	}
+
+	return nil
+}
+
+// ShopperInteraction is a type definition for sales channel, payment is made through
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/v52/payments__reqParam_shopperInteraction
+type ShopperInteraction string
+
+// Shopper interactions
+const (
+	// ShopperInteractionEcommerce - online payment, made by a shopper
+	ShopperInteractionEcommerce ShopperInteraction = "Ecommerce"
+	// ShopperInteractionContAuth - payment with stored details, made by a merchant without a shopper
+	ShopperInteractionContAuth ShopperInteraction = "ContAuth"
+	// ShopperInteractionMoto - mail order or telephone order payment
+	ShopperInteractionMoto ShopperInteraction = "Moto"
+	// ShopperInteractionPOS - point of sale payment, made with a terminal
+	ShopperInteractionPOS ShopperInteraction = "POS"
+)
+
+// Validate - checks that shopper interaction is known
+func (s ShopperInteraction) Validate() error {
+	switch s {
+	case ShopperInteractionEcommerce, ShopperInteractionContAuth, ShopperInteractionMoto, ShopperInteractionPOS:
+		return nil
+	}
+
+	return fmt.Errorf("unknown shopper interaction %q", string(s))
+}
+
+// RecurringProcessingModel is a type definition for the kind of payments, made with stored details
+//
+// Link - https://docs.adyen.com/api-explorer/#/CheckoutService/v52/payments__reqParam_recurringProcessingModel
+type RecurringProcessingModel string
+
+// Recurring processing models
+const (
+	// RecurringProcessingModelCardOnFile - payment with stored details, initiated by a shopper
+	RecurringProcessingModelCardOnFile RecurringProcessingModel = "CardOnFile"
+	// RecurringProcessingModelSubscription - payment on a fixed schedule, initiated by a merchant
+	RecurringProcessingModelSubscription RecurringProcessingModel = "Subscription"
+	// RecurringProcessingModelUnscheduledCardOnFile - payment without a fixed schedule, initiated by a merchant
+	RecurringProcessingModelUnscheduledCardOnFile RecurringProcessingModel = "UnscheduledCardOnFile"
+)
+
+// Validate - checks that recurring processing model is known
+func (m RecurringProcessingModel) Validate() error {
+	switch m {
+	case RecurringProcessingModelCardOnFile, RecurringProcessingModelSubscription, RecurringProcessingModelUnscheduledCardOnFile:
+		return nil
+	}
+
+	return fmt.Errorf("unknown recurring processing model %q", string(m))
+}
+
+// recurringParams - recurring related fields of a payment request
+type recurringParams struct {
+	contract         ContractType
+	interaction      ShopperInteraction
+	model            RecurringProcessingModel
+	shopperReference string
+	detailReference  string
+	storeDetails     bool
+}
+
+// validate - checks recurring fields of a payment request before it is sent
+//
+// Empty fields are not validated, since Adyen falls back to defaults of the merchant account.
+// Rules checked:
+//
+//   - contract, shopper interaction and recurring processing model are known values
+//   - storing or using stored payment details requires shopper reference
+//   - ContAuth payment requires stored payment details and RECURRING contract, if contract is specified
+//   - ContAuth payment can't be made with CardOnFile model, that is used for shopper initiated payments
+func (p recurringParams) validate() error {
+	if p.contract != "" {
+		if err := p.contract.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.interaction != "" {
+		if err := p.interaction.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.model != "" {
+		if err := p.model.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.shopperReference == "" {
+		switch {
+		case p.contract != "" || p.storeDetails:
+			return errors.New("shopper reference is required to store payment details")
+		case p.detailReference != "":
+			return errors.New("shopper reference is required to use stored payment details")
+		case p.model != "":
+			return fmt.Errorf("shopper reference is required for %s recurring processing model", p.model)
+		}
+	}
+
+	if p.interaction != ShopperInteractionContAuth {
+		return nil
+	}
+
+	if p.shopperReference == "" || p.detailReference == "" {
+		return errors.New("shopper reference and recurring detail reference are required for ContAuth payment")
+	}
+
+	if p.contract != "" && !p.contract.Has(ContractRecurring) {
+		return fmt.Errorf("ContAuth payment requires %s contract, got %s", ContractRecurring, p.contract)
+	}
+
+	if p.model == RecurringProcessingModelCardOnFile {
+		return fmt.Errorf("ContAuth payment can't be made with %s recurring processing model", p.model)
+	}
+
+	return nil
+}
+
+// recurringContract - returns contract of optional recurring settings
+func recurringContract(r *Recurring) ContractType {
+	if r == nil {
+		return ""
+	}
+
+	return r.Contract
+}
+
+// ValidateRecurring - checks recurring contract, shopper interaction and references of authorise request
+func (r *Authorise) ValidateRecurring() error {
+	return recurringParams{
+		contract:         recurringContract(r.Recurring),
+		interaction:      r.ShopperInteraction,
+		shopperReference: r.ShopperReference,
+		detailReference:  r.SelectedRecurringDetailReference,
+	}.validate()
+}
+
+// ValidateRecurring - checks recurring contract, shopper interaction and references of authorise request
+func (r *AuthoriseEncrypted) ValidateRecurring() error {
+	return recurringParams{
+		contract:         recurringContract(r.Recurring),
+		interaction:      r.ShopperInteraction,
+		shopperReference: r.ShopperReference,
+		detailReference:  r.SelectedRecurringDetailReference,
+	}.validate()
+}
+
+// ValidateRecurring - checks shopper interaction, recurring processing model and references of payments request
+//
+// Stored payment details are referenced with StoredPaymentMethodID or RecurringDetailReference of PaymentMethod
+func (r *PaymentsRequest) ValidateRecurring() error {
+	p := recurringParams{
+		interaction:      r.ShopperInteraction,
+		model:            r.RecurringProcessingModel,
+		shopperReference: r.ShopperReference,
+		storeDetails:     r.StorePaymentMethod,
+	}
+
+	if r.PaymentMethod != nil {
+		p.detailReference = r.PaymentMethod.StoredPaymentMethodID
+		if p.detailReference == "" {
+			p.detailReference = r.PaymentMethod.RecurringDetailReference
+		}
+	}
+
+	return p.validate()
+}
+
+// ValidateRecurring - checks shopper interaction, recurring processing model and shopper reference of sessions request
+//
+// Session can't reference stored payment details, so ContAuth is not allowed
+func (r *CreateCheckoutSessionRequest) ValidateRecurring() error {
+	return recurringParams{
+		interaction:      r.ShopperInteraction,
+		model:            r.RecurringProcessingModel,
+		shopperReference: r.ShopperReference,
+		storeDetails:     r.StorePaymentMethod,
+	}.validate()
+}
@@ -0,0 +1,197 @@
+package adyen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContractType(t *testing.T) {
+	equals(t, ContractOneClickRecurring, Contracts(ContractOneClick, ContractRecurring))
+	equals(t, ContractType("RECURRING,PAYOUT"), Contracts(ContractRecurring, "", ContractPayout))
+
+	assert(t, ContractOneClickRecurring.Has(ContractRecurring), "expected ONECLICK,RECURRING to have RECURRING contract")
+	assert(t, !ContractOneClick.Has(ContractRecurring), "expected ONECLICK not to have RECURRING contract")
+
+	cases := []struct {
+		contract ContractType
+		valid    bool
+	}{
+		{ContractOneClick, true},
+		{ContractRecurring, true},
+		{ContractPayout, true},
+		{ContractOneClickRecurring, true},
+		{"RECURRING,PAYOUT", true},
+		{"", false},
+		{"recurring", false},
+		{"ONECLICK,", false},
+		{"RECURRING,RECURRING", false},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.contract), func(t *testing.T) {
+			err := c.contract.Validate()
+			equals(t, c.valid, err == nil)
+		})
+	}
+}
+
+func TestRecurringParamsValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		params recurringParams
+		valid  bool
+	}{
+		{
+			name:  "no recurring fields",
+			valid: true,
+		},
+		{
+			name: "store details",
+			params: recurringParams{
+				contract:         ContractOneClickRecurring,
+				interaction:      ShopperInteractionEcommerce,
+				shopperReference: "shopper-1",
+			},
+			valid: true,
+		},
+		{
+			name: "store details without shopper reference",
+			params: recurringParams{
+				contract: ContractRecurring,
+			},
+		},
+		{
+			name: "store payment method without shopper reference",
+			params: recurringParams{
+				storeDetails: true,
+			},
+		},
+		{
+			name: "stored details without shopper reference",
+			params: recurringParams{
+				detailReference: "8316131829360006",
+			},
+		},
+		{
+			name: "recurring processing model without shopper reference",
+			params: recurringParams{
+				model: RecurringProcessingModelSubscription,
+			},
+		},
+		{
+			name: "ContAuth",
+			params: recurringParams{
+				contract:         ContractRecurring,
+				interaction:      ShopperInteractionContAuth,
+				model:            RecurringProcessingModelUnscheduledCardOnFile,
+				shopperReference: "shopper-1",
+				detailReference:  SelectRecurringDetailReferenceLatests,
+			},
+			valid: true,
+		},
+		{
+			name: "ContAuth without detail reference",
+			params: recurringParams{
+				contract:         ContractRecurring,
+				interaction:      ShopperInteractionContAuth,
+				shopperReference: "shopper-1",
+			},
+		},
+		{
+			name: "ContAuth without shopper reference",
+			params: recurringParams{
+				interaction:     ShopperInteractionContAuth,
+				detailReference: "8316131829360006",
+			},
+		},
+		{
+			name: "ContAuth with ONECLICK contract",
+			params: recurringParams{
+				contract:         ContractOneClick,
+				interaction:      ShopperInteractionContAuth,
+				shopperReference: "shopper-1",
+				detailReference:  "8316131829360006",
+			},
+		},
+		{
+			name: "ContAuth with CardOnFile model",
+			params: recurringParams{
+				interaction:      ShopperInteractionContAuth,
+				model:            RecurringProcessingModelCardOnFile,
+				shopperReference: "shopper-1",
+				detailReference:  "8316131829360006",
+			},
+		},
+		{
+			name: "unknown shopper interaction",
+			params: recurringParams{
+				interaction: "Online",
+			},
+		},
+		{
+			name: "unknown recurring processing model",
+			params: recurringParams{
+				model:            "Installments",
+				shopperReference: "shopper-1",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.params.validate()
+			equals(t, c.valid, err == nil)
+		})
+	}
+}
+
+func TestPaymentsRequestValidateRecurring(t *testing.T) {
+	req := &PaymentsRequest{
+		ShopperInteraction:       ShopperInteractionContAuth,
+		RecurringProcessingModel: RecurringProcessingModelSubscription,
+		ShopperReference:         "shopper-1",
+		PaymentMethod:            &CheckoutPaymentMethod{Type: "scheme"},
+	}
+	assert(t, req.ValidateRecurring() != nil, "expected ContAuth payment without stored payment method to be invalid")
+
+	req.PaymentMethod.StoredPaymentMethodID = "8316131829360006"
+	equals(t, nil, req.ValidateRecurring())
+}
+
+func TestSessionsRequestValidateRecurring(t *testing.T) {
+	req := &CreateCheckoutSessionRequest{
+		StorePaymentMethod:       true,
+		RecurringProcessingModel: RecurringProcessingModelCardOnFile,
+	}
+	assert(t, req.ValidateRecurring() != nil, "expected storing payment method without shopper reference to be invalid")
+
+	req.ShopperReference = "shopper-1"
+	equals(t, nil, req.ValidateRecurring())
+
+	req.ShopperInteraction = ShopperInteractionContAuth
+	assert(t, req.ValidateRecurring() != nil, "expected ContAuth session to be invalid")
+}
+
+func TestAuthoriseInvalidRecurringIsNotSent(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer srv.Close()
+
+	_, err := getTestInstanceWithServer(srv).Payment().AuthoriseContext(context.Background(), &Authorise{
+		Amount:             &Amount{Value: 1000, Currency: "EUR"},
+		Reference:          "DE-TEST-1",
+		MerchantAccount:    "merchant",
+		ShopperReference:   "shopper-1",
+		ShopperInteraction: ShopperInteractionContAuth,
+		Recurring:          &Recurring{Contract: ContractRecurring},
+	})
+
+	assert(t, err != nil, "expected ContAuth payment without recurring detail reference to fail")
+	equals(t, 0, calls)
+}
@@ -0,0 +1,68 @@
+package adyen
+
+import (
+	"strconv"
+	"time"
+)
+
+// CardExpiry - returns the moment stored card expires, that is the beginning of the month after expiry month
+//
+// Returns false if recurring detail has no valid card expiry date, f.e. for bank accounts or wallets
+func (d RecurringDetail) CardExpiry() (time.Time, bool) {
+	month, err := strconv.Atoi(d.Card.ExpireMonth)
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+
+	year, err := strconv.Atoi(d.Card.ExpireYear)
+	if err != nil || year < 1 {
+		return time.Time{}, false
+	}
+
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// ExpiresBefore - checks if stored card is expired by a given time, f.e. to schedule account updater in advance
+//
+// Details without card expiry date never expire
+func (d RecurringDetail) ExpiresBefore(t time.Time) bool {
+	expiry, ok := d.CardExpiry()
+	if !ok {
+		return false
+	}
+
+	return !expiry.After(t)
+}
+
+// HasContract - checks if details are stored for a given contract, f.e. ContractRecurring
+func (d RecurringDetail) HasContract(contract ContractType) bool {
+	for _, c := range d.ContractTypes {
+		if ContractType(c) == contract {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RecurringDetails - returns all recurring details of the result
+func (r *RecurringDetailsResult) RecurringDetails() []RecurringDetail {
+	details := make([]RecurringDetail, 0, len(r.Details))
+	for _, d := range r.Details {
+		details = append(details, d.RecurringDetail)
+	}
+
+	return details
+}
+
+// ExpiringBefore - returns recurring details with cards expired by a given time
+func (r *RecurringDetailsResult) ExpiringBefore(t time.Time) []RecurringDetail {
+	var details []RecurringDetail
+	for _, d := range r.Details {
+		if d.RecurringDetail.ExpiresBefore(t) {
+			details = append(details, d.RecurringDetail)
+		}
+	}
+
+	return details
+}
@@ -0,0 +1,157 @@
+package adyen
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const testRecurringDetailsJSON = `{
+	"creationDate": "2021-03-01T10:00:00+01:00",
+	"details": [
+		{
+			"RecurringDetail": {
+				"additionalData": {
+					"cardBin": "411111",
+					"networkTxReference": "MCC123456789"
+				},
+				"alias": "K333136193308394",
+				"aliasType": "Default",
+				"billingAddress": {
+					"city": "Amsterdam",
+					"country": "NL",
+					"houseNumberOrName": "6-50",
+					"postalCode": "1011 DJ",
+					"street": "Simon Carmiggeltstraat"
+				},
+				"card": {
+					"expiryMonth": "3",
+					"expiryYear": "2021",
+					"holderName": "John Smith",
+					"number": "1111"
+				},
+				"contractTypes": ["RECURRING", "ONECLICK"],
+				"recurringDetailReference": "8415336862463792",
+				"variant": "visa"
+			}
+		},
+		{
+			"RecurringDetail": {
+				"bank": {
+					"iban": "NL13TEST0123456789",
+					"ownerName": "A. Schneider",
+					"countryCode": "NL"
+				},
+				"contractTypes": ["RECURRING"],
+				"recurringDetailReference": "8316131829360006",
+				"shopperName": {
+					"firstName": "Anna",
+					"gender": "FEMALE",
+					"lastName": "Schneider"
+				},
+				"socialSecurityNumber": "123456789",
+				"variant": "sepadirectdebit"
+			}
+		},
+		{
+			"RecurringDetail": {
+				"contractTypes": ["RECURRING"],
+				"recurringDetailReference": "8316131829360010",
+				"tokenDetails": {
+					"tokenData": {
+						"EmailId": "shopper@example.com",
+						"BillingAgreementId": "B-2DL91234AB123456C"
+					},
+					"tokenDataType": "PayPal"
+				},
+				"variant": "paypal"
+			}
+		},
+		{
+			"RecurringDetail": {
+				"contractTypes": ["RECURRING"],
+				"elv": {
+					"accountHolderName": "Max Mustermann",
+					"bankAccountNumber": "1234567890",
+					"bankLocationId": "12345678"
+				},
+				"recurringDetailReference": "8316131829360014",
+				"variant": "elv"
+			}
+		}
+	],
+	"shopperReference": "shopper-1"
+}`
+
+func TestRecurringDetails(t *testing.T) {
+	var res RecurringDetailsResult
+	if err := json.Unmarshal([]byte(testRecurringDetailsJSON), &res); err != nil {
+		t.Fatalf("error unmarshalling json: %v", err)
+	}
+
+	details := res.RecurringDetails()
+	equals(t, 4, len(details))
+
+	card, sepa, paypal, elv := details[0], details[1], details[2], details[3]
+
+	equals(t, "411111", card.AdditionalData.CardBin)
+	equals(t, ExtraData{"networkTxReference": "MCC123456789"}, card.AdditionalData.Extra)
+	equals(t, "Amsterdam", card.BillingAddress.City)
+	assert(t, card.HasContract(ContractOneClick), "expected card to be stored for ONECLICK contract")
+	assert(t, !card.HasContract(ContractPayout), "expected card not to be stored for PAYOUT contract")
+
+	equals(t, &BankAccount{IBAN: "NL13TEST0123456789", OwnerName: "A. Schneider", CountryCode: "NL"}, sepa.Bank)
+	equals(t, "Schneider", sepa.ShopperName.LastName)
+	equals(t, "123456789", sepa.SocialSecurityNumber)
+
+	equals(t, "PayPal", paypal.TokenDetails.TokenDataType)
+	equals(t, "shopper@example.com", paypal.TokenDetails.TokenData["EmailId"])
+
+	equals(t, &ELV{AccountHolderName: "Max Mustermann", BankAccountNumber: "1234567890", BankLocationID: "12345678"}, elv.Elv)
+
+	// card is valid till the end of expiry month
+	assert(t, !card.ExpiresBefore(time.Date(2021, time.March, 31, 23, 59, 0, 0, time.UTC)), "expected card to be valid in expiry month")
+	assert(t, card.ExpiresBefore(time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)), "expected card to be expired after expiry month")
+	assert(t, !sepa.ExpiresBefore(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)), "expected bank account never to expire")
+
+	expiring := res.ExpiringBefore(time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC))
+	equals(t, 1, len(expiring))
+	equals(t, "8415336862463792", expiring[0].RecurringDetailReference)
+}
+
+func TestRecurringDetailCardExpiry(t *testing.T) {
+	cases := []struct {
+		name  string
+		card  Card
+		exp   time.Time
+		expOk bool
+	}{
+		{
+			name:  "single digit month",
+			card:  Card{ExpireMonth: "8", ExpireYear: "2018"},
+			exp:   time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+			expOk: true,
+		},
+		{
+			name:  "december",
+			card:  Card{ExpireMonth: "12", ExpireYear: "2030"},
+			exp:   time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expOk: true,
+		},
+		{
+			name: "no card",
+		},
+		{
+			name: "invalid month",
+			card: Card{ExpireMonth: "13", ExpireYear: "2030"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expiry, ok := RecurringDetail{Card: c.card}.CardExpiry()
+			equals(t, c.expOk, ok)
+			equals(t, c.exp, expiry)
+		})
+	}
+}
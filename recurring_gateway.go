@@ -1,5 +1,7 @@
 package adyen
 
+import "context"
+
 // RecurringGateway - Adyen recurring transaction logic
 type RecurringGateway struct {
 	*Adyen
@@ -10,13 +12,28 @@ const (
 	listRecurringDetailsType = "listRecurringDetails"
 	// disableRecurringType - disable recurring type request, @TODO: move to enums
 	disableRecurringType = "disable"
+	// storeTokenType - store token type request
+	storeTokenType = "storeToken"
+	// notifyShopperType - notify shopper type request
+	notifyShopperType = "notifyShopper"
+	// scheduleAccountUpdaterType - schedule account updater type request
+	scheduleAccountUpdaterType = "scheduleAccountUpdater"
+	// createPermitType - create permit type request
+	createPermitType = "createPermit"
+	// disablePermitType - disable permit type request
+	disablePermitType = "disablePermit"
 )
 
 // ListRecurringDetails - Get list of recurring payments in Adyen
 func (a *RecurringGateway) ListRecurringDetails(req *RecurringDetailsRequest) (*RecurringDetailsResult, error) {
+	return a.ListRecurringDetailsContext(context.Background(), req)
+}
+
+// ListRecurringDetailsContext - Get list of recurring payments in Adyen, bound to a given context
+func (a *RecurringGateway) ListRecurringDetailsContext(ctx context.Context, req *RecurringDetailsRequest) (*RecurringDetailsResult, error) {
 	url := a.adyenURL(RecurringService, listRecurringDetailsType, RecurringAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -27,9 +44,14 @@ func (a *RecurringGateway) ListRecurringDetails(req *RecurringDetailsRequest) (*
 
 // DisableRecurring - disable customer's saved payment method based on a contract type or/and payment method ID
 func (a *RecurringGateway) DisableRecurring(req *RecurringDisableRequest) (*RecurringDisableResponse, error) {
+	return a.DisableRecurringContext(context.Background(), req)
+}
+
+// DisableRecurringContext - disable customer's saved payment method, bound to a given context
+func (a *RecurringGateway) DisableRecurringContext(ctx context.Context, req *RecurringDisableRequest) (*RecurringDisableResponse, error) {
 	url := a.adyenURL(RecurringService, disableRecurringType, RecurringAPIVersion)
 
-	resp, err := a.execute(url, req)
+	resp, err := a.execute(ctx, url, req)
 
 	if err != nil {
 		return nil, err
@@ -37,3 +59,93 @@ func (a *RecurringGateway) DisableRecurring(req *RecurringDisableRequest) (*Recu
 
 	return resp.disableRecurring()
 }
+
+// StoreToken - tokenize shopper's card or bank account details without a payment
+func (a *RecurringGateway) StoreToken(req *StoreTokenRequest) (*StoreTokenResult, error) {
+	return a.StoreTokenContext(context.Background(), req)
+}
+
+// StoreTokenContext - tokenize shopper's card or bank account details, bound to a given context
+func (a *RecurringGateway) StoreTokenContext(ctx context.Context, req *StoreTokenRequest) (*StoreTokenResult, error) {
+	url := a.adyenURL(RecurringService, storeTokenType, RecurringAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.storeToken()
+}
+
+// NotifyShopper - send pre-debit notification to a shopper before a recurring payment
+func (a *RecurringGateway) NotifyShopper(req *NotifyShopperRequest) (*NotifyShopperResult, error) {
+	return a.NotifyShopperContext(context.Background(), req)
+}
+
+// NotifyShopperContext - send pre-debit notification to a shopper, bound to a given context
+func (a *RecurringGateway) NotifyShopperContext(ctx context.Context, req *NotifyShopperRequest) (*NotifyShopperResult, error) {
+	url := a.adyenURL(RecurringService, notifyShopperType, RecurringNotifyShopperAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.notifyShopper()
+}
+
+// ScheduleAccountUpdater - schedule update of stored card details with Account Updater
+func (a *RecurringGateway) ScheduleAccountUpdater(req *ScheduleAccountUpdaterRequest) (*ScheduleAccountUpdaterResult, error) {
+	return a.ScheduleAccountUpdaterContext(context.Background(), req)
+}
+
+// ScheduleAccountUpdaterContext - schedule update of stored card details, bound to a given context
+func (a *RecurringGateway) ScheduleAccountUpdaterContext(ctx context.Context, req *ScheduleAccountUpdaterRequest) (*ScheduleAccountUpdaterResult, error) {
+	url := a.adyenURL(RecurringService, scheduleAccountUpdaterType, RecurringAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.scheduleAccountUpdater()
+}
+
+// CreatePermit - create permits for partners to make payments with shopper's stored payment details
+func (a *RecurringGateway) CreatePermit(req *CreatePermitRequest) (*CreatePermitResult, error) {
+	return a.CreatePermitContext(context.Background(), req)
+}
+
+// CreatePermitContext - create permits for stored payment details, bound to a given context
+func (a *RecurringGateway) CreatePermitContext(ctx context.Context, req *CreatePermitRequest) (*CreatePermitResult, error) {
+	url := a.adyenURL(RecurringService, createPermitType, RecurringPermitsAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.createPermit()
+}
+
+// DisablePermit - disable previously created permit
+func (a *RecurringGateway) DisablePermit(req *DisablePermitRequest) (*DisablePermitResult, error) {
+	return a.DisablePermitContext(context.Background(), req)
+}
+
+// DisablePermitContext - disable previously created permit, bound to a given context
+func (a *RecurringGateway) DisablePermitContext(ctx context.Context, req *DisablePermitRequest) (*DisablePermitResult, error) {
+	url := a.adyenURL(RecurringService, disablePermitType, RecurringPermitsAPIVersion)
+
+	resp, err := a.execute(ctx, url, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.disablePermit()
+}
@@ -0,0 +1,204 @@
+package adyen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recurringTestServer - test server to record request path and body, responding with a given body
+func recurringTestServer(t *testing.T, path *string, body *map[string]interface{}, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*path = r.URL.Path
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading request body: %v", err)
+		}
+
+		if err := json.Unmarshal(b, body); err != nil {
+			t.Errorf("error unmarshalling json: %v", err)
+		}
+
+		fmt.Fprint(w, response)
+	}))
+}
+
+func TestStoreToken(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var body map[string]interface{}
+	srv := recurringTestServer(t, &path, &body, `{
+		"pspReference": "8516131829360071",
+		"recurringDetailReference": "8316131829360006",
+		"result": "Success"
+	}`)
+	defer srv.Close()
+
+	res, err := getTestInstanceWithServer(srv).Recurring().StoreToken(&StoreTokenRequest{
+		MerchantAccount:  "merchant",
+		ShopperReference: "shopper-1",
+		ShopperEmail:     "shopper@example.com",
+		Recurring:        &Recurring{Contract: ContractRecurring},
+		BankAccount: &BankAccount{
+			IBAN:        "NL13TEST0123456789",
+			OwnerName:   "A. Schneider",
+			CountryCode: "NL",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Recurring/"+RecurringAPIVersion+"/storeToken/", path)
+	equals(t, map[string]interface{}{
+		"iban":        "NL13TEST0123456789",
+		"ownerName":   "A. Schneider",
+		"countryCode": "NL",
+	}, body["bank"])
+	equals(t, &StoreTokenResult{
+		PspReference:             "8516131829360071",
+		RecurringDetailReference: "8316131829360006",
+		Result:                   StoreTokenResultSuccess,
+	}, res)
+}
+
+func TestNotifyShopper(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var body map[string]interface{}
+	srv := recurringTestServer(t, &path, &body, `{
+		"pspReference": "8516167336214570",
+		"reference": "notification-1",
+		"resultCode": "Success",
+		"message": "Request processed successfully",
+		"displayedReference": "Example displayed reference",
+		"shopperNotificationReference": "QXKAP5Z6MPB6P3HSC4WZ",
+		"storedPaymentMethodId": "8416167336214573"
+	}`)
+	defer srv.Close()
+
+	res, err := getTestInstanceWithServer(srv).Recurring().NotifyShopper(&NotifyShopperRequest{
+		MerchantAccount:       "merchant",
+		ShopperReference:      "shopper-1",
+		Reference:             "notification-1",
+		Amount:                &Amount{Value: 2000, Currency: "INR"},
+		StoredPaymentMethodID: "8416167336214573",
+		BillingDate:           "2021-03-16",
+		DisplayedReference:    "Example displayed reference",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Recurring/"+RecurringNotifyShopperAPIVersion+"/notifyShopper/", path)
+	equals(t, "2021-03-16", body["billingDate"])
+	equals(t, "Success", res.ResultCode)
+	equals(t, "QXKAP5Z6MPB6P3HSC4WZ", res.ShopperNotificationReference)
+	equals(t, "8416167336214573", res.StoredPaymentMethodID)
+}
+
+func TestScheduleAccountUpdater(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var body map[string]interface{}
+	srv := recurringTestServer(t, &path, &body, `{
+		"pspReference": "8815329842815468",
+		"result": "Success"
+	}`)
+	defer srv.Close()
+
+	res, err := getTestInstanceWithServer(srv).Recurring().ScheduleAccountUpdater(&ScheduleAccountUpdaterRequest{
+		MerchantAccount:                  "merchant",
+		Reference:                        "update-1",
+		ShopperReference:                 "shopper-1",
+		SelectedRecurringDetailReference: "8316131829360006",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Recurring/"+RecurringAPIVersion+"/scheduleAccountUpdater/", path)
+	equals(t, "8316131829360006", body["selectedRecurringDetailReference"])
+	_, hasCard := body["card"]
+	assert(t, !hasCard, "empty card should not be sent")
+	equals(t, &ScheduleAccountUpdaterResult{PspReference: "8815329842815468", Result: "Success"}, res)
+}
+
+func TestCreatePermit(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var body map[string]interface{}
+	srv := recurringTestServer(t, &path, &body, `{
+		"pspReference": "8816178914079738",
+		"permitResultList": [
+			{"resultKey": "permit-1", "token": "AGTTZ7UXJPM6RTX3DQXZHV3R"}
+		]
+	}`)
+	defer srv.Close()
+
+	res, err := getTestInstanceWithServer(srv).Recurring().CreatePermit(&CreatePermitRequest{
+		MerchantAccount:          "merchant",
+		ShopperReference:         "shopper-1",
+		RecurringDetailReference: "8316131829360006",
+		Permits: []Permit{
+			{
+				PartnerID: "partner-1",
+				ResultKey: "permit-1",
+				Restriction: &PermitRestriction{
+					MaxAmount: &Amount{Value: 10000, Currency: "EUR"},
+					SingleUse: true,
+				},
+				ValidTillDate: "2021-12-31T23:59:59+01:00",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Recurring/"+RecurringPermitsAPIVersion+"/createPermit/", path)
+	equals(t, []interface{}{
+		map[string]interface{}{
+			"partnerId": "partner-1",
+			"resultKey": "permit-1",
+			"restriction": map[string]interface{}{
+				"maxAmount": map[string]interface{}{"value": float64(10000), "currency": "EUR"},
+				"singleUse": true,
+			},
+			"validTillDate": "2021-12-31T23:59:59+01:00",
+		},
+	}, body["permits"])
+	equals(t, &CreatePermitResult{
+		PspReference:     "8816178914079738",
+		PermitResultList: []PermitResult{{ResultKey: "permit-1", Token: "AGTTZ7UXJPM6RTX3DQXZHV3R"}},
+	}, res)
+}
+
+func TestDisablePermit(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var body map[string]interface{}
+	srv := recurringTestServer(t, &path, &body, `{"pspReference": "8816178914079742", "status": "disabled"}`)
+	defer srv.Close()
+
+	res, err := getTestInstanceWithServer(srv).Recurring().DisablePermit(&DisablePermitRequest{
+		MerchantAccount: "merchant",
+		Token:           "AGTTZ7UXJPM6RTX3DQXZHV3R",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "/Recurring/"+RecurringPermitsAPIVersion+"/disablePermit/", path)
+	equals(t, "AGTTZ7UXJPM6RTX3DQXZHV3R", body["token"])
+	equals(t, &DisablePermitResult{PspReference: "8816178914079742", Status: "disabled"}, res)
+}
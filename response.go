@@ -21,7 +21,13 @@ type Response struct {
 }
 
 // handleHTTPError - handle non 200 response from Adyen and create Error response instance
+//
+// Body is decoded as APIError only for error statuses, successful responses are left to endpoint parsers
 func (r *Response) handleHTTPError() error {
+	if r.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
 	var aerr APIError
 	if err := json.Unmarshal(r.Body, &aerr); err != nil {
 		return err
@@ -141,6 +147,66 @@ func (r *Response) disableRecurring() (*RecurringDisableResponse, error) {
 	return &a, nil
 }
 
+// storeToken - generate Adyen store token response
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/storeToken__resParam
+func (r *Response) storeToken() (*StoreTokenResult, error) {
+	var a StoreTokenResult
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// notifyShopper - generate Adyen notify shopper response
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/notifyShopper__resParam
+func (r *Response) notifyShopper() (*NotifyShopperResult, error) {
+	var a NotifyShopperResult
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// scheduleAccountUpdater - generate Adyen schedule account updater response
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/scheduleAccountUpdater__resParam
+func (r *Response) scheduleAccountUpdater() (*ScheduleAccountUpdaterResult, error) {
+	var a ScheduleAccountUpdaterResult
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// createPermit - generate Adyen create permit response
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/createPermit__resParam
+func (r *Response) createPermit() (*CreatePermitResult, error) {
+	var a CreatePermitResult
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// disablePermit - generate Adyen disable permit response
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v68/post/disablePermit__resParam
+func (r *Response) disablePermit() (*DisablePermitResult, error) {
+	var a DisablePermitResult
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
 // paymentMethods - generate Adyen CheckoutAPI paymentMethods response.
 func (r *Response) paymentMethods() (*PaymentMethodsResponse, error) {
 	var a PaymentMethodsResponse
@@ -150,3 +216,93 @@ func (r *Response) paymentMethods() (*PaymentMethodsResponse, error) {
 
 	return &a, nil
 }
+
+// payments - generate Adyen CheckoutAPI payments response.
+func (r *Response) payments() (*PaymentsResponse, error) {
+	var a PaymentsResponse
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentDetails - generate Adyen CheckoutAPI payments/details response.
+func (r *Response) paymentDetails() (*PaymentDetailsResponse, error) {
+	var a PaymentDetailsResponse
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// sessions - generate Adyen CheckoutAPI sessions response.
+func (r *Response) sessions() (*CreateCheckoutSessionResponse, error) {
+	var a CreateCheckoutSessionResponse
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentLink - generate Adyen CheckoutAPI paymentLinks response.
+func (r *Response) paymentLink() (*PaymentLinkResponse, error) {
+	var a PaymentLinkResponse
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentCapture - generate Adyen CheckoutAPI payment captures response.
+func (r *Response) paymentCapture() (*PaymentCaptureResource, error) {
+	var a PaymentCaptureResource
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentRefund - generate Adyen CheckoutAPI payment refunds response.
+func (r *Response) paymentRefund() (*PaymentRefundResource, error) {
+	var a PaymentRefundResource
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentCancel - generate Adyen CheckoutAPI payment cancels response.
+func (r *Response) paymentCancel() (*PaymentCancelResource, error) {
+	var a PaymentCancelResource
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentReversal - generate Adyen CheckoutAPI payment reversals response.
+func (r *Response) paymentReversal() (*PaymentReversalResource, error) {
+	var a PaymentReversalResource
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// paymentAmountUpdate - generate Adyen CheckoutAPI payment amountUpdates response.
+func (r *Response) paymentAmountUpdate() (*PaymentAmountUpdateResource, error) {
+	var a PaymentAmountUpdateResource
+	if err := json.Unmarshal(r.Body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
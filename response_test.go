@@ -16,7 +16,7 @@ func TestResponseErrorResponseStatus(t *testing.T) {
 	"status"    : 501
 }
 `
-	providerResponse, err := createTestResponse(responseJSON, "OK 200", 200)
+	providerResponse, err := createTestResponse(responseJSON, "501 Not Implemented", 501)
 
 	if err != nil {
 		t.Fatal(err)
@@ -33,6 +33,18 @@ func TestResponseErrorResponseStatus(t *testing.T) {
 	}
 }
 
+// TestResponseSuccessfulStatus - successful response body isn't decoded as error, f.e. payment link with own "status" field
+func TestResponseSuccessfulStatus(t *testing.T) {
+	t.Parallel()
+
+	providerResponse, err := createTestResponse(`{"id":"PL50C5F751CED39G71","status":"active"}`, "OK 200", 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, nil, providerResponse.handleHTTPError())
+}
+
 // TestResponseNotValidJson - response is an empty script, error should be returned
 func TestResponseNotValidJson(t *testing.T) {
 	t.Parallel()
@@ -526,3 +538,17 @@ func TestTechnicalCancelResponse(t *testing.T) {
 		})
 	}
 }
+
+// TestResponseStringStatus - successful response with own "status" field shouldn't be treated as an error
+func TestResponseStringStatus(t *testing.T) {
+	t.Parallel()
+
+	providerResponse, err := createTestResponse(`{"id":"PL50C5F751CED39G71","status":"active"}`, "OK 200", 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = providerResponse.handleHTTPError(); err != nil {
+		t.Fatalf("Response should not raise an error - got %v", err)
+	}
+}
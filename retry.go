@@ -0,0 +1,133 @@
+package adyen
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"net/http"
+	"time"
+)
+
+// idempotencyKeyHeader - header used by Adyen to identify retried requests
+//
+// Link - https://docs.adyen.com/development-resources/api-idempotency
+const idempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	// DefaultRetryMaxAttempts - default number of attempts, including the first one
+	DefaultRetryMaxAttempts = 3
+
+	// DefaultRetryInitialBackoff - default delay before the first retry
+	DefaultRetryInitialBackoff = time.Millisecond * 200
+
+	// DefaultRetryMaxBackoff - default upper bound of a delay between retries
+	DefaultRetryMaxBackoff = time.Second * 5
+)
+
+// RetryPolicy - configuration of automatic retries for API calls
+//
+// Description:
+//
+//   - MaxAttempts - total number of attempts, including the first one
+//   - InitialBackoff - delay before the first retry, doubled on every next retry
+//   - MaxBackoff - upper bound of a delay between retries
+//
+// Zero values are replaced with DefaultRetryMaxAttempts, DefaultRetryInitialBackoff and DefaultRetryMaxBackoff.
+// Actual delay is randomized between zero and calculated backoff (full jitter)
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx carrying Idempotency-Key for the API call
+//
+// Use it with any ...Context gateway method, f.e.:
+//
+//	ctx := adyen.WithIdempotencyKey(ctx, "order-123-authorise")
+//	res, err := instance.Payment().AuthoriseContext(ctx, req)
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKeyFromContext returns Idempotency-Key stored by WithIdempotencyKey
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// newIdempotencyKey generates random UUID v4 to be used as Idempotency-Key
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// maxAttempts returns total number of attempts allowed by the policy
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil {
+		return 1
+	}
+
+	if p.MaxAttempts <= 0 {
+		return DefaultRetryMaxAttempts
+	}
+
+	return p.MaxAttempts
+}
+
+// shouldRetry checks if a given attempt result could be safely retried
+//
+// Network errors, 5xx and 429 responses are retried, unless ctx is already done
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, r *Response, err error) bool {
+	if attempt >= p.maxAttempts() || ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return r.StatusCode >= http.StatusInternalServerError || r.StatusCode == http.StatusTooManyRequests
+}
+
+// backoff returns randomized delay before next attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = DefaultRetryInitialBackoff
+	}
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+
+	d := time.Duration(float64(initial) * math.Pow(2, float64(attempt-1)))
+	if d > max || d <= 0 {
+		d = max
+	}
+
+	return time.Duration(mrand.Int63n(int64(d) + 1))
+}
+
+// wait blocks until next attempt could be performed or ctx is done
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(p.backoff(attempt))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
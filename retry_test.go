@@ -0,0 +1,156 @@
+package adyen
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecuteIdempotencyKeyFromContext(t *testing.T) {
+	t.Parallel()
+
+	var key string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get(idempotencyKeyHeader)
+		fmt.Fprint(w, `{"pspReference":"8413547924770610","response":"[refund-received]"}`)
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	ctx := WithIdempotencyKey(context.Background(), "refund-order-1")
+	if _, err := instance.Modification().RefundContext(ctx, &Refund{}); err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, "refund-order-1", key)
+}
+
+func TestExecuteWithoutRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert(t, r.Header.Get(idempotencyKeyHeader) == "", "Idempotency-Key should not be sent by default")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	instance := getTestInstanceWithServer(srv)
+
+	if _, err := instance.Modification().Capture(&Capture{}); err == nil {
+		t.Fatal("Request should fail, due to unavailable service")
+	}
+
+	equals(t, 1, calls)
+}
+
+func TestExecuteRetryPolicy(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []int
+		expCalls int
+		expErr   bool
+	}{
+		{
+			name:     "retry on 5xx until success",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expCalls: 3,
+		},
+		{
+			name:     "retry on 429",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			expCalls: 2,
+		},
+		{
+			name:     "do not retry on 4xx",
+			statuses: []int{http.StatusUnprocessableEntity, http.StatusOK},
+			expCalls: 1,
+			expErr:   true,
+		},
+		{
+			name:     "stop after max attempts",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			expCalls: 3,
+			expErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu   sync.Mutex
+				keys []string
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+				status := c.statuses[len(keys)-1]
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					fmt.Fprint(w, `{"pspReference":"8413547924770610","resultCode":"Authorised"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"status":%d,"errorCode":"000","message":"error","errorType":"internal"}`, status)
+			}))
+			defer srv.Close()
+
+			instance := getTestInstanceWithServer(srv)
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond * 5})(instance)
+
+			_, err := instance.Payment().Authorise(&Authorise{})
+			if (err != nil) != c.expErr {
+				t.Fatalf("expected error?: %t, actual error: %v", c.expErr, err)
+			}
+
+			equals(t, c.expCalls, len(keys))
+			for _, k := range keys {
+				assert(t, k != "" && k == keys[0], "Every attempt should be sent with the same Idempotency-Key")
+			}
+		})
+	}
+}
+
+func TestExecuteRetryPolicyContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	instance := New(Environment{apiURL: srv.URL}, "username", "password", WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := instance.Payment().AuthoriseContext(ctx, &Authorise{}); err == nil {
+		t.Fatal("Request should fail, due to cancelled context")
+	}
+
+	assert(t, time.Since(start) < time.Second*5, "Retries should stop as soon as context is done")
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: time.Millisecond * 100, MaxBackoff: time.Millisecond * 300}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		d := p.backoff(attempt)
+		assert(t, d >= 0 && d <= p.MaxBackoff, fmt.Sprintf("Backoff %s should not exceed %s", d, p.MaxBackoff))
+	}
+}
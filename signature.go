@@ -6,8 +6,10 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
-	"strconv"
+	"sort"
 	"strings"
+
+	"github.com/google/go-querystring/query"
 )
 
 // replaceSpecialChars replace special characters according to Adyen documentation
@@ -20,140 +22,121 @@ func replaceSpecialChars(value string) string {
 	return temp
 }
 
+// hppSignatureField - HPP field with the signature itself, excluded from signing
+const hppSignatureField = "merchantSig"
+
 // CalculateSignature calculate HMAC signature for request
 //
+// All fields with url tag, that are going to be sent, are signed, see SignHPPFields
+//
 // Link: https://docs.adyen.com/developers/payments/accepting-payments/hmac-signature-calculation
 func (r *DirectoryLookupRequest) CalculateSignature(adyen *Adyen) error {
 	if r.MerchantAccount == "" || r.SkinCode == "" || adyen.Credentials.Hmac == "" {
 		return errors.New("merchantID, skinCode and HMAC hash need to be specified")
 	}
 
-	keyString := strings.Join([]string{
-		"countryCode",
-		"currencyCode",
-		"merchantAccount",
-		"merchantReference",
-		"paymentAmount",
-		"sessionValidity",
-		"shipBeforeDate",
-		"skinCode",
-	}, ":")
-
-	valueString := strings.Join([]string{
-		replaceSpecialChars(r.CountryCode),
-		replaceSpecialChars(r.CurrencyCode),
-		replaceSpecialChars(r.MerchantAccount),
-		replaceSpecialChars(r.MerchantReference),
-		replaceSpecialChars(strconv.Itoa(r.PaymentAmount)),
-		replaceSpecialChars(r.SessionsValidity),
-		replaceSpecialChars(r.ShipBeforeDate),
-		replaceSpecialChars(r.SkinCode),
-	}, ":")
-
-	fullString := keyString + ":" + valueString
-
-	src, err := hex.DecodeString(adyen.Credentials.Hmac)
+	sig, err := signHPPRequest(r, adyen.Credentials.Hmac)
 	if err != nil {
 		return err
 	}
 
-	mac := hmac.New(sha256.New, src)
-	if _, err = mac.Write([]byte(fullString)); err != nil {
-		return err
-	}
-
-	r.MerchantSig = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	r.MerchantSig = sig
 	return nil
 }
 
 // CalculateSignature calculate HMAC signature for request
 //
+// All fields with url tag, that are going to be sent, are signed, see SignHPPFields.
+// Open invoice fields are signed separately into openinvoicedata.sig as well
+//
 // Link: https://docs.adyen.com/developers/payments/accepting-payments/hmac-signature-calculation
 func (r *SkipHppRequest) CalculateSignature(adyen *Adyen) error {
 	if r.MerchantAccount == "" || r.SkinCode == "" || adyen.Credentials.Hmac == "" {
 		return errors.New("merchantID, skinCode and HMAC hash need to be specified")
 	}
 
-	keyString := strings.Join([]string{
-		"brandCode",
-		"countryCode",
-		"currencyCode",
-		"issuerId",
-		"merchantAccount",
-		"merchantReference",
-		"paymentAmount",
-		"sessionValidity",
-		"shipBeforeDate",
-		"shopperLocale",
-		"skinCode",
-	}, ":")
-
-	valueString := strings.Join([]string{
-		replaceSpecialChars(r.BrandCode),
-		replaceSpecialChars(r.CountryCode),
-		replaceSpecialChars(r.CurrencyCode),
-		replaceSpecialChars(r.IssuerID),
-		replaceSpecialChars(r.MerchantAccount),
-		replaceSpecialChars(r.MerchantReference),
-		replaceSpecialChars(strconv.Itoa(r.PaymentAmount)),
-		replaceSpecialChars(r.SessionsValidity),
-		replaceSpecialChars(r.ShipBeforeDate),
-		replaceSpecialChars(r.ShopperLocale),
-		replaceSpecialChars(r.SkinCode),
-	}, ":")
-
-	fullString := keyString + ":" + valueString
-
-	src, err := hex.DecodeString(adyen.Credentials.Hmac)
-	if err != nil {
-		return err
+	if r.OpenInvoiceData != nil {
+		if err := r.OpenInvoiceData.sign(adyen.Credentials.Hmac); err != nil {
+			return err
+		}
 	}
 
-	mac := hmac.New(sha256.New, src)
-	if _, err = mac.Write([]byte(fullString)); err != nil {
+	sig, err := signHPPRequest(r, adyen.Credentials.Hmac)
+	if err != nil {
 		return err
 	}
 
-	r.MerchantSig = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	r.MerchantSig = sig
 	return nil
 }
 
+// SignHPPFields calculate HMAC signature for a given set of HPP fields
+//
+// Field names are sorted, then names and values are escaped and joined with ":" into
+// "name1:name2:...:value1:value2:..." string, that is signed with HMAC-SHA256 using hex-encoded key.
+// merchantSig field is ignored. Signature is returned base64-encoded.
+//
+// Link: https://docs.adyen.com/developers/payments/accepting-payments/hmac-signature-calculation
+func SignHPPFields(fields map[string]string, hmacKey string) (string, error) {
+	key, err := hex.DecodeString(hmacKey)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if name != hppSignatureField {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for i, name := range names {
+		values = append(values, replaceSpecialChars(fields[name]))
+		names[i] = replaceSpecialChars(name)
+	}
+
+	signingString := strings.Join(append(names, values...), ":")
+
+	mac := hmac.New(sha256.New, key)
+	if _, err = mac.Write([]byte(signingString)); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// signHPPRequest - calculate HMAC signature of all fields of HPP request, encoded with url tags
+func signHPPRequest(req interface{}, hmacKey string) (string, error) {
+	v, err := query.Values(req)
+	if err != nil {
+		return "", err
+	}
+
+	fields := make(map[string]string, len(v))
+	for name := range v {
+		fields[name] = v.Get(name)
+	}
+
+	return SignHPPFields(fields, hmacKey)
+}
+
 // ValidateSignature validate HMAC signature for notification event
 //
+// Signature is accepted if it matches primary HMAC key or any verification key valid at the moment,
+// see WithHMACVerificationKey.
+//
 // Link: https://docs.adyen.com/development-resources/notifications/verify-hmac-signatures#verify-using-your-own-solution
 func (n *NotificationRequestItemData) ValidateSignature(adyen *Adyen) (bool, error) {
-	var precondition error
-	providedSig := n.AdditionalData.HmacSignature
-	if len(providedSig) == 0 {
-		precondition = errors.New("no HMAC signature in message")
-	} else if len(adyen.Credentials.Hmac) == 0 {
-		precondition = errors.New("no HMAC key configured; cannot validate signature")
-	}
-	if precondition != nil {
-		return false, precondition
+	if len(n.AdditionalData.HmacSignature) == 0 {
+		return false, errNoHMACSignature
 	}
 
-	valueInJavaFloatToStringStyle := strconv.FormatFloat(float64(n.Amount.Value), 'f', -1, 32)
-	valueString := strings.Join([]string{
-		replaceSpecialChars(n.PspReference),
-		replaceSpecialChars(n.OriginalReference),
-		replaceSpecialChars(n.MerchantAccountCode),
-		replaceSpecialChars(n.MerchantReference),
-		valueInJavaFloatToStringStyle,
-		replaceSpecialChars(n.Amount.Currency),
-		replaceSpecialChars(n.EventCode),
-		strconv.FormatBool(bool(n.Success)),
-	}, ":")
-
-	src, err := hex.DecodeString(adyen.Credentials.Hmac)
+	v, err := adyen.NotificationVerifier()
 	if err != nil {
 		return false, err
 	}
 
-	mac := hmac.New(sha256.New, src)
-	if _, err = mac.Write([]byte(valueString)); err != nil {
-		return false, err
-	}
-	expectedSig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
-	return expectedSig == providedSig, nil
+	return v.VerifyItem(n) == nil, nil
 }
@@ -1,9 +1,14 @@
 package adyen
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-querystring/query"
@@ -166,3 +171,96 @@ func TestSignatureNotification(t *testing.T) {
 		})
 	}
 }
+
+// legacyHPPSignature - signature calculation with a fixed list of fields, as it was done before SignHPPFields
+func legacyHPPSignature(t *testing.T, hmacKey string, names []string, values []string) string {
+	escaped := make([]string, 0, len(values))
+	for _, v := range values {
+		escaped = append(escaped, replaceSpecialChars(v))
+	}
+
+	key, err := hex.DecodeString(hmacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(strings.Join(names, ":") + ":" + strings.Join(escaped, ":")))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignatureMatchesFixedFieldList(t *testing.T) {
+	t.Parallel()
+
+	instance := NewWithHMAC(Testing, "username", "password", testNotificationHMAC)
+
+	lookup := DirectoryLookupRequest{
+		CurrencyCode:      "EUR",
+		MerchantAccount:   "TestMerchant",
+		ShipBeforeDate:    "2015-11-31T13:42:40+1:00",
+		PaymentAmount:     1000,
+		SkinCode:          "skin",
+		MerchantReference: "DE-100100GMWJGS",
+		SessionsValidity:  "2015-11-29T13:42:40+1:00",
+	}
+	if err := lookup.CalculateSignature(instance); err != nil {
+		t.Fatal(err)
+	}
+
+	equals(t, legacyHPPSignature(t, testNotificationHMAC,
+		[]string{"countryCode", "currencyCode", "merchantAccount", "merchantReference", "paymentAmount", "sessionValidity", "shipBeforeDate", "skinCode"},
+		[]string{"", "EUR", "TestMerchant", "DE-100100GMWJGS", "1000", "2015-11-29T13:42:40+1:00", "2015-11-31T13:42:40+1:00", "skin"},
+	), lookup.MerchantSig)
+
+	skip := SkipHppRequest{
+		MerchantReference: "DE-100100GMWJGS",
+		PaymentAmount:     1000,
+		CurrencyCode:      "EUR",
+		ShipBeforeDate:    "2015-11-31T13:42:40+1:00",
+		SkinCode:          "skin",
+		MerchantAccount:   "TestMerchant",
+		ShopperLocale:     "en_GB",
+		SessionsValidity:  "2015-11-29T13:42:40+1:00",
+		CountryCode:       "NL",
+		BrandCode:         "ideal",
+		IssuerID:          "1121",
+	}
+	if err := skip.CalculateSignature(instance); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := legacyHPPSignature(t, testNotificationHMAC,
+		[]string{"brandCode", "countryCode", "currencyCode", "issuerId", "merchantAccount", "merchantReference", "paymentAmount", "sessionValidity", "shipBeforeDate", "shopperLocale", "skinCode"},
+		[]string{"ideal", "NL", "EUR", "1121", "TestMerchant", "DE-100100GMWJGS", "1000", "2015-11-29T13:42:40+1:00", "2015-11-31T13:42:40+1:00", "en_GB", "skin"},
+	)
+	equals(t, legacy, skip.MerchantSig)
+
+	// optional fields are signed once specified
+	skip.ShopperEmail = "shopper@example.com"
+	if err := skip.CalculateSignature(instance); err != nil {
+		t.Fatal(err)
+	}
+	assert(t, legacy != skip.MerchantSig, "expected shopperEmail to be signed")
+}
+
+func TestSignHPPFields(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]string{
+		"merchantAccount":   "TestMerchant",
+		"merchantReference": `order:1\a`,
+		"paymentAmount":     "1000",
+		"merchantSig":       "ignored",
+	}
+
+	sig, err := SignHPPFields(fields, testNotificationHMAC)
+	equals(t, nil, err)
+	equals(t, legacyHPPSignature(t, testNotificationHMAC,
+		[]string{"merchantAccount", "merchantReference", "paymentAmount"},
+		[]string{"TestMerchant", `order:1\a`, "1000"},
+	), sig)
+
+	_, err = SignHPPFields(fields, "not-a-hex-key")
+	assert(t, err != nil, "expected malformed key to be rejected")
+}
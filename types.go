@@ -38,6 +38,25 @@ type Card struct {
 	HolderName  string `json:"holderName"`
 }
 
+/***************
+* Bank account *
+***************/
+
+// BankAccount - bank account details, f.e. to store SEPA Direct Debit or ELV token
+//
+// Link - https://docs.adyen.com/api-explorer/#/Recurring/v49/storeToken__reqParam_bank
+type BankAccount struct {
+	BankAccountNumber string `json:"bankAccountNumber,omitempty"`
+	BankCity          string `json:"bankCity,omitempty"`
+	BankLocationID    string `json:"bankLocationId,omitempty"`
+	BankName          string `json:"bankName,omitempty"`
+	BIC               string `json:"bic,omitempty"`
+	CountryCode       string `json:"countryCode,omitempty"`
+	IBAN              string `json:"iban,omitempty"`
+	OwnerName         string `json:"ownerName,omitempty"`
+	TaxID             string `json:"taxId,omitempty"`
+}
+
 /*******
 * Name *
 *******/